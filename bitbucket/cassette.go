@@ -0,0 +1,272 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// CassetteEnvVar is the path of the cassette the provider records its http traffic to or replays it from
+	CassetteEnvVar = "BITBUCKET_CASSETTE"
+	// CassetteModeEnvVar selects whether the cassette is recorded or replayed
+	CassetteModeEnvVar = "BITBUCKET_CASSETTE_MODE"
+
+	cassetteModeRecord = "record"
+	cassetteModeReplay = "replay"
+)
+
+var (
+	uuidPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	// uuids that were normalized already are kept as they are
+	cassetteUUIDPattern = regexp.MustCompile(`^00000000-0000-4000-8000-\d{12}$`)
+)
+
+// cassetteRequest is a sanitized request, it is matched against the requests sent on replay
+type cassetteRequest struct {
+	Method string `json:"method"`
+	URI    string `json:"uri"`
+	Body   string `json:"body,omitempty"`
+}
+
+// cassetteResponse is a sanitized response as returned on replay
+type cassetteResponse struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+
+	replayed bool
+}
+
+// cassette holds the http interactions of one acceptance test. Every provider configured while the test runs shares
+// the same cassette, so the interactions of all test steps end up in order in one file.
+type cassette struct {
+	mu           sync.Mutex
+	path         string
+	mode         string
+	Interactions []*cassetteInteraction `json:"interactions"`
+
+	// replacements map recorded values, such as random names or the workspace, to stable placeholders
+	replacements []string
+	uuids        map[string]string
+}
+
+var (
+	cassettesMu sync.Mutex
+	cassettes   = make(map[string]*cassette)
+)
+
+// openCassette returns the cassette stored at path. Replayed cassettes are loaded from disk the first time they
+// are opened, recorded cassettes start out empty.
+func openCassette(path, mode string) (*cassette, error) {
+	if mode != cassetteModeRecord && mode != cassetteModeReplay {
+		return nil, fmt.Errorf("%s must be %q or %q, got %q", CassetteModeEnvVar, cassetteModeRecord, cassetteModeReplay, mode)
+	}
+
+	cassettesMu.Lock()
+	defer cassettesMu.Unlock()
+
+	if c, ok := cassettes[path]; ok {
+		if c.mode != mode {
+			return nil, fmt.Errorf("cassette %s is already open for %s", path, c.mode)
+		}
+		return c, nil
+	}
+
+	c := &cassette{
+		path:  path,
+		mode:  mode,
+		uuids: make(map[string]string),
+	}
+
+	if mode == cassetteModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, c); err != nil {
+			return nil, fmt.Errorf("error decoding cassette %s: %w", path, err)
+		}
+	}
+
+	cassettes[path] = c
+	return c, nil
+}
+
+// replace records that value has to be stored as placeholder
+func (c *cassette) replace(value, placeholder string) {
+	if value == "" || value == placeholder {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.replacements = append(c.replacements, value, placeholder)
+}
+
+// close writes a recorded cassette to disk and forgets it, so the next test opens it afresh
+func (c *cassette) close() error {
+	cassettesMu.Lock()
+	delete(cassettes, c.path)
+	cassettesMu.Unlock()
+
+	if c.mode != cassetteModeRecord {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(c.path, append(data, '\n'), 0644)
+}
+
+// sanitize replaces credentials, secrets, uuids and the registered values in s by stable placeholders. It has to
+// be called with c.mu held.
+func (c *cassette) sanitize(s string) string {
+	if len(c.replacements) > 0 {
+		s = strings.NewReplacer(c.replacements...).Replace(s)
+	}
+
+	return uuidPattern.ReplaceAllStringFunc(s, func(uuid string) string {
+		if cassetteUUIDPattern.MatchString(uuid) {
+			return uuid
+		}
+
+		uuid = strings.ToLower(uuid)
+		if placeholder, ok := c.uuids[uuid]; ok {
+			return placeholder
+		}
+
+		placeholder := fmt.Sprintf("00000000-0000-4000-8000-%012d", len(c.uuids)+1)
+		c.uuids[uuid] = placeholder
+		return placeholder
+	})
+}
+
+func (c *cassette) sanitizeBody(payload []byte, contentType string) string {
+	return c.sanitize(redactBody(payload, contentType))
+}
+
+// cassetteTransport records the traffic of the provider to a cassette or replays it from one, depending on the mode
+// of the cassette. Requests never reach the network on replay.
+type cassetteTransport struct {
+	cassette  *cassette
+	transport http.RoundTripper
+}
+
+func newCassetteTransport(c *cassette, transport http.RoundTripper) *cassetteTransport {
+	return &cassetteTransport{cassette: c, transport: transport}
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var payload []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		payload, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(payload))
+	}
+
+	if t.cassette.mode == cassetteModeReplay {
+		return t.replay(req, payload)
+	}
+
+	return t.record(req, payload)
+}
+
+func (t *cassetteTransport) record(req *http.Request, payload []byte) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	c := t.cassette
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Interactions = append(c.Interactions, &cassetteInteraction{
+		Request: cassetteRequest{
+			Method: req.Method,
+			URI:    c.sanitize(req.URL.RequestURI()),
+			Body:   c.sanitizeBody(payload, req.Header.Get("Content-Type")),
+		},
+		Response: cassetteResponse{
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        c.sanitizeBody(body, resp.Header.Get("Content-Type")),
+		},
+	})
+
+	return resp, nil
+}
+
+func (t *cassetteTransport) replay(req *http.Request, payload []byte) (*http.Response, error) {
+	c := t.cassette
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	want := cassetteRequest{
+		Method: req.Method,
+		URI:    c.sanitize(req.URL.RequestURI()),
+		Body:   c.sanitizeBody(payload, req.Header.Get("Content-Type")),
+	}
+
+	// identical requests, such as repeated reads, are answered in the order they were recorded
+	for _, interaction := range c.Interactions {
+		if interaction.replayed || interaction.Request != want {
+			continue
+		}
+		interaction.replayed = true
+
+		header := make(http.Header)
+		if interaction.Response.ContentType != "" {
+			header.Set("Content-Type", interaction.Response.ContentType)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cassette %s has no recorded response left for %s %s", c.path, want.Method, want.URI)
+}
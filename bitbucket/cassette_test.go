@@ -0,0 +1,122 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCassetteRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	lifecycle := func(t *testing.T, f *fakeBitbucket, mode string) {
+		name := testAccCassetteAt(t, path, mode).name("tf-test")
+
+		testOfflineLifecycle(t, f, offlineLifecycle{
+			resource: "bitbucket_repository",
+			steps: []map[string]interface{}{
+				{"name": name},
+				{"name": name, "description": "updated"},
+			},
+			check: func(t *testing.T, step int, state *terraform.InstanceState) {
+				if state.Attributes["name"] != name {
+					t.Errorf("expected name %q, got %q", name, state.Attributes["name"])
+				}
+			},
+			importID: func(state *terraform.InstanceState) string { return state.ID },
+		})
+	}
+
+	t.Run("record", func(t *testing.T) {
+		lifecycle(t, newFakeBitbucket(t), cassetteModeRecord)
+	})
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected the cassette to be written: %s", err)
+	}
+	for _, leaked := range []string{"fake-password", "{00000001-", "Authorization"} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("expected %q to be sanitized from the cassette", leaked)
+		}
+	}
+	if !strings.Contains(string(data), "tf-test-0000000000000000001") {
+		t.Error("expected the random name to be normalized")
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		// the fake is gone, every response has to come from the cassette
+		f := newFakeBitbucket(t)
+		f.Close()
+
+		lifecycle(t, f, cassetteModeReplay)
+	})
+}
+
+func TestCassetteRandom(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	generate := func(t *testing.T, random *testAccRandom) []string {
+		publicKey, _, err := random.sshKeyPair(1024, "tf-acc-user")
+		if err != nil {
+			t.Fatalf("error generating random SSH key: %s", err)
+		}
+		return []string{random.name("tf-test"), random.stringFromCharSet(10, "ABCDEFGHIJKLMNOPQRSTUVWXYZ"), publicKey}
+	}
+
+	var recorded []string
+	t.Run("record", func(t *testing.T) {
+		random := testAccCassetteAt(t, path, cassetteModeRecord)
+		for _, value := range generate(t, random) {
+			random.cassette.mu.Lock()
+			recorded = append(recorded, random.cassette.sanitize(value))
+			random.cassette.mu.Unlock()
+		}
+	})
+
+	// the random values are stored as the placeholders the replay generates
+	want := []string{"tf-test-0000000000000000002", "AAAAAAAAAD", "ssh-rsa AAAAtfacckey0000000000000000001 tf-acc-user"}
+	if fmt.Sprint(recorded) != fmt.Sprint(want) {
+		t.Errorf("expected the recorded values %q, got %q", want, recorded)
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		if replayed := generate(t, testAccCassetteAt(t, path, cassetteModeReplay)); fmt.Sprint(replayed) != fmt.Sprint(want) {
+			t.Errorf("expected the replayed values %q, got %q", want, replayed)
+		}
+	})
+}
+
+func TestCassetteSanitize(t *testing.T) {
+	c := &cassette{uuids: make(map[string]string)}
+	c.replace("my-team", cassetteWorkspace)
+
+	got := c.sanitizeBody([]byte(`{"key":"k","value":"hunter2","secured":true,"uuid":"{1B2C3D4E-0000-1111-2222-333344445555}",`+
+		`"owner":"my-team","other":"{1b2c3d4e-0000-1111-2222-333344445555}"}`), "application/json")
+	want := `{"key":"k","other":"{00000000-0000-4000-8000-000000000001}","owner":"tf-acc-workspace","secured":true,` +
+		`"uuid":"{00000000-0000-4000-8000-000000000001}","value":"[REDACTED]"}`
+
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	if uri := c.sanitize("/2.0/repositories/my-team/repo/hooks/%7B00000000-0000-4000-8000-000000000001%7D"); uri != "/2.0/repositories/tf-acc-workspace/repo/hooks/%7B00000000-0000-4000-8000-000000000001%7D" {
+		t.Errorf("unexpected uri %s", uri)
+	}
+}
+
+func TestCassetteReplayMiss(t *testing.T) {
+	c := &cassette{mode: cassetteModeReplay, path: "test.json", uuids: make(map[string]string)}
+	client := &Client{HTTPClient: &http.Client{Transport: newCassetteTransport(c, nil)}}
+
+	_, err := client.Get(context.Background(), "2.0/repositories/ws/repo")
+	if err == nil || !strings.Contains(err.Error(), "no recorded response left for GET /2.0/repositories/ws/repo") {
+		t.Errorf("expected a missing interaction to fail, got %v", err)
+	}
+}
@@ -2,26 +2,152 @@ package bitbucket
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
+	"golang.org/x/oauth2"
 )
 
 // Error represents a error from the bitbucket api.
 type Error struct {
 	APIError struct {
 		Message string `json:"message,omitempty"`
+		Detail  string `json:"detail,omitempty"`
+		// Fields holds the errors of the invalid fields of the request by their name
+		Fields ErrorFields `json:"fields,omitempty"`
 	} `json:"error,omitempty"`
+	// Errors is the envelope of Bitbucket Data Center, the context of an error names the invalid field
+	Errors []struct {
+		Context string `json:"context,omitempty"`
+		Message string `json:"message,omitempty"`
+	} `json:"errors,omitempty"`
 	Type       string `json:"type,omitempty"`
 	StatusCode int
 	Endpoint   string
 }
 
 func (e Error) Error() string {
-	return fmt.Sprintf("API Error: %d %s %s", e.StatusCode, e.Endpoint, e.APIError.Message)
+	msg := fmt.Sprintf("API Error: %d %s %s", e.StatusCode, e.Endpoint, e.message())
+	if e.APIError.Detail != "" {
+		msg += ": " + e.APIError.Detail
+	}
+	for _, field := range e.fieldErrors() {
+		msg += fmt.Sprintf(", %s: %s", field.field, field.message)
+	}
+	return msg
+}
+
+// message returns the message of the error, Data Center errors without a context are messages of the whole request
+func (e Error) message() string {
+	if e.APIError.Message != "" {
+		return e.APIError.Message
+	}
+
+	var messages []string
+	for _, err := range e.Errors {
+		if err.Context == "" {
+			messages = append(messages, err.Message)
+		}
+	}
+	return strings.Join(messages, ", ")
+}
+
+type fieldError struct {
+	field   string
+	message string
+}
+
+// fieldErrors returns the errors of the fields of the request ordered by field
+func (e Error) fieldErrors() []fieldError {
+	var errs []fieldError
+	for field, messages := range e.APIError.Fields {
+		for _, message := range messages {
+			errs = append(errs, fieldError{field: field, message: message})
+		}
+	}
+	for _, err := range e.Errors {
+		if err.Context != "" {
+			errs = append(errs, fieldError{field: err.Context, message: err.Message})
+		}
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].field < errs[j].field })
+
+	return errs
+}
+
+// ErrorFields are the errors of the fields of a request. Bitbucket sends a list of messages for most fields and a
+// single message for some.
+type ErrorFields map[string][]string
+
+func (f *ErrorFields) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	fields := make(ErrorFields, len(raw))
+	for field, value := range raw {
+		var messages []string
+		if err := json.Unmarshal(value, &messages); err != nil {
+			var message string
+			if err := json.Unmarshal(value, &message); err != nil {
+				message = string(value)
+			}
+			messages = []string{message}
+		}
+		fields[field] = messages
+	}
+
+	*f = fields
+	return nil
+}
+
+// AsError returns the Error of the api that caused err. Errors of the generated api client are decoded from the
+// body of the response.
+func AsError(err error) (Error, bool) {
+	var apiErr Error
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+
+	var swaggerErr bitbucket.GenericSwaggerError
+	if errors.As(err, &swaggerErr) {
+		if json.Unmarshal(swaggerErr.Body(), &apiErr) != nil {
+			return Error{}, false
+		}
+		apiErr.StatusCode, _ = strconv.Atoi(strings.SplitN(swaggerErr.Error(), " ", 2)[0])
+		return apiErr, true
+	}
+
+	return Error{}, false
+}
+
+// IsNotFound reports whether err was caused by a 404 response of either the internal Client or the generated
+// api client.
+func IsNotFound(err error) bool {
+	var notFound *bitbucketapi.NotFoundError
+	if errors.As(err, &notFound) {
+		return true
+	}
+
+	var swaggerErr bitbucket.GenericSwaggerError
+	if errors.As(err, &swaggerErr) {
+		return strings.HasPrefix(swaggerErr.Error(), strconv.Itoa(http.StatusNotFound))
+	}
+
+	return false
 }
 
 const (
@@ -35,23 +161,46 @@ type Client struct {
 	Username   *string
 	Password   *string
 	OAuthToken *string
-	HTTPClient *http.Client
+	// TokenSource provides refreshable oauth access tokens, it takes precedence over OAuthToken
+	TokenSource oauth2.TokenSource
+	HTTPClient  *http.Client
+	// BaseURL overrides BitbucketEndpoint, it must end with a slash
+	BaseURL string
+}
+
+// baseURL returns the configured api url or the default bitbucket endpoint
+func (c *Client) baseURL() string {
+	if c.BaseURL == "" {
+		return BitbucketEndpoint
+	}
+	return c.BaseURL
 }
 
-// Do Will just call the bitbucket api but also add auth to it and some extra headers
-func (c *Client) Do(method, endpoint string, payload *bytes.Buffer, addJsonHeader bool) (*http.Response, error) {
+// absoluteURL resolves endpoint against the configured api url. Absolute urls, such as the `next` links
+// returned by paginated endpoints, are rebased onto the configured api url when they point at bitbucket.
+func (c *Client) absoluteURL(endpoint string) string {
+	if strings.HasPrefix(endpoint, "https://") || strings.HasPrefix(endpoint, "http://") {
+		if strings.HasPrefix(endpoint, BitbucketEndpoint) {
+			return c.baseURL() + strings.TrimPrefix(endpoint, BitbucketEndpoint)
+		}
+		return endpoint
+	}
+	return c.baseURL() + strings.TrimPrefix(endpoint, "/")
+}
 
-	absoluteendpoint := BitbucketEndpoint + endpoint
-	log.Printf("[DEBUG] Sending request to %s %s", method, absoluteendpoint)
+// Do Will just call the bitbucket api but also add auth to it and some extra headers. The request is aborted
+// when ctx is cancelled or its deadline passes.
+func (c *Client) Do(ctx context.Context, method, endpoint string, payload *bytes.Buffer, addJsonHeader bool) (*http.Response, error) {
+
+	absoluteendpoint := c.absoluteURL(endpoint)
 
 	var bodyreader io.Reader
 
 	if payload != nil {
-		log.Printf("[DEBUG] With payload %s", payload.String())
 		bodyreader = payload
 	}
 
-	req, err := http.NewRequest(method, absoluteendpoint, bodyreader)
+	req, err := http.NewRequestWithContext(ctx, method, absoluteendpoint, bodyreader)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +210,14 @@ func (c *Client) Do(method, endpoint string, payload *bytes.Buffer, addJsonHeade
 		req.SetBasicAuth(*c.Username, *c.Password)
 	}
 
-	if c.OAuthToken != nil {
+	if c.TokenSource != nil {
+		log.Printf("[DEBUG] Setting Bearer Token from OAuth client credentials")
+		token, err := c.TokenSource.Token()
+		if err != nil {
+			return nil, fmt.Errorf("error obtaining oauth access token: %w", err)
+		}
+		req.Header.Add("Authorization", "Bearer "+token.AccessToken)
+	} else if c.OAuthToken != nil {
 		log.Printf("[DEBUG] Setting Bearer Token")
 		var bearer = "Bearer " + *c.OAuthToken
 		req.Header.Add("Authorization", bearer)
@@ -75,7 +231,10 @@ func (c *Client) Do(method, endpoint string, payload *bytes.Buffer, addJsonHeade
 	req.Close = true
 
 	resp, err := c.HTTPClient.Do(req)
-	log.Printf("[DEBUG] Resp: %v Err: %v", resp, err)
+	if err != nil {
+		return nil, err
+	}
+
 	if resp.StatusCode >= 400 || resp.StatusCode < 200 {
 		apiError := Error{
 			StatusCode: resp.StatusCode,
@@ -87,13 +246,15 @@ func (c *Client) Do(method, endpoint string, payload *bytes.Buffer, addJsonHeade
 			return nil, err
 		}
 
-		log.Printf("[DEBUG] Resp Body: %s", string(body))
-
 		err = json.Unmarshal(body, &apiError)
 		if err != nil {
 			apiError.APIError.Message = string(body)
 		}
 
+		if resp.StatusCode == http.StatusNotFound {
+			return resp, &bitbucketapi.NotFoundError{Endpoint: endpoint, Err: apiError}
+		}
+
 		return resp, error(apiError)
 
 	}
@@ -101,31 +262,31 @@ func (c *Client) Do(method, endpoint string, payload *bytes.Buffer, addJsonHeade
 }
 
 // Get is just a helper method to do but with a GET verb
-func (c *Client) Get(endpoint string) (*http.Response, error) {
-	return c.Do("GET", endpoint, nil, true)
+func (c *Client) Get(ctx context.Context, endpoint string) (*http.Response, error) {
+	return c.Do(ctx, "GET", endpoint, nil, true)
 }
 
 // Post is just a helper method to do but with a POST verb
-func (c *Client) Post(endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
-	return c.Do("POST", endpoint, jsonpayload, true)
+func (c *Client) Post(ctx context.Context, endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
+	return c.Do(ctx, "POST", endpoint, jsonpayload, true)
 }
 
 // PostNonJson is just a helper method to do but with a POST verb without Json Header
-func (c *Client) PostNonJson(endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
-	return c.Do("POST", endpoint, jsonpayload, false)
+func (c *Client) PostNonJson(ctx context.Context, endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
+	return c.Do(ctx, "POST", endpoint, jsonpayload, false)
 }
 
 // Put is just a helper method to do but with a PUT verb
-func (c *Client) Put(endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
-	return c.Do("PUT", endpoint, jsonpayload, true)
+func (c *Client) Put(ctx context.Context, endpoint string, jsonpayload *bytes.Buffer) (*http.Response, error) {
+	return c.Do(ctx, "PUT", endpoint, jsonpayload, true)
 }
 
 // PutOnly is just a helper method to do but with a PUT verb and a nil body
-func (c *Client) PutOnly(endpoint string) (*http.Response, error) {
-	return c.Do("PUT", endpoint, nil, true)
+func (c *Client) PutOnly(ctx context.Context, endpoint string) (*http.Response, error) {
+	return c.Do(ctx, "PUT", endpoint, nil, true)
 }
 
 // Delete is just a helper to Do but with a DELETE verb
-func (c *Client) Delete(endpoint string) (*http.Response, error) {
-	return c.Do("DELETE", endpoint, nil, true)
+func (c *Client) Delete(ctx context.Context, endpoint string) (*http.Response, error) {
+	return c.Do(ctx, "DELETE", endpoint, nil, true)
 }
@@ -0,0 +1,79 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DrFaust92/bitbucket-go-client"
+)
+
+func TestClientAbsoluteURL(t *testing.T) {
+	cases := []struct {
+		baseURL  string
+		endpoint string
+		expected string
+	}{
+		{"", "2.0/user", "https://api.bitbucket.org/2.0/user"},
+		{"https://proxy.internal/bitbucket/", "2.0/user", "https://proxy.internal/bitbucket/2.0/user"},
+		{"https://proxy.internal/bitbucket/", "/1.0/groups/ws", "https://proxy.internal/bitbucket/1.0/groups/ws"},
+		{"https://proxy.internal/bitbucket/", "https://api.bitbucket.org/2.0/workspaces/ws/members?page=2", "https://proxy.internal/bitbucket/2.0/workspaces/ws/members?page=2"},
+		{"http://127.0.0.1:8080/", "http://127.0.0.1:8080/2.0/workspaces/ws/members?page=2", "http://127.0.0.1:8080/2.0/workspaces/ws/members?page=2"},
+	}
+
+	for _, tc := range cases {
+		client := &Client{BaseURL: tc.baseURL}
+		if got := client.absoluteURL(tc.endpoint); got != tc.expected {
+			t.Errorf("absoluteURL(%q) with base %q: expected %q, got %q", tc.endpoint, tc.baseURL, tc.expected, got)
+		}
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/2.0/repositories/ws/gone":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type": "error", "error": {"message": "Repository ws/gone not found"}}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"type": "error", "error": {"message": "Access denied"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL + "/"}
+
+	_, err := client.Get(context.Background(), "2.0/repositories/ws/gone")
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	var apiErr Error
+	if !errors.As(err, &apiErr) || apiErr.APIError.Message != "Repository ws/gone not found" {
+		t.Errorf("expected the api error to be wrapped, got %v", err)
+	}
+
+	_, err = client.Get(context.Background(), "2.0/repositories/ws/private")
+	if err == nil || IsNotFound(err) {
+		t.Errorf("expected a non not found error, got %v", err)
+	}
+
+	conf := bitbucket.NewConfiguration()
+	conf.BasePath = server.URL + "/2.0"
+	conf.HTTPClient = server.Client()
+	apiClient := bitbucket.NewAPIClient(conf)
+
+	_, _, err = apiClient.RepositoriesApi.RepositoriesWorkspaceRepoSlugGet(context.Background(), "gone", "ws")
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error from the generated client, got %v", err)
+	}
+
+	_, _, err = apiClient.RepositoriesApi.RepositoriesWorkspaceRepoSlugGet(context.Background(), "private", "ws")
+	if err == nil || IsNotFound(err) {
+		t.Errorf("expected a non not found error from the generated client, got %v", err)
+	}
+}
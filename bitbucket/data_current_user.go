@@ -1,22 +1,16 @@
 package bitbucket
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
-type PaginatedUserEmails struct {
-	Values []UserEmail `json:"values,omitempty"`
-	Page   int         `json:"page,omitempty"`
-	Size   int         `json:"size,omitempty"`
-	Next   string      `json:"next,omitempty"`
-}
-
 type UserEmail struct {
 	Email       string `json:"email"`
 	IsPrimary   bool   `json:"is_primary"`
@@ -25,7 +19,7 @@ type UserEmail struct {
 
 func dataCurrentUser() *schema.Resource {
 	return &schema.Resource{
-		Read: dataReadCurrentUser,
+		ReadContext: dataReadCurrentUser,
 
 		Schema: map[string]*schema.Schema{
 			"username": {
@@ -64,43 +58,29 @@ func dataCurrentUser() *schema.Resource {
 	}
 }
 
-func dataReadCurrentUser(d *schema.ResourceData, m interface{}) error {
+func dataReadCurrentUser(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	httpClient := m.(Clients).httpClient
 	usersApi := c.ApiClient.UsersApi
 
-	curUser, curUserRes, err := usersApi.UserGet(c.AuthContext)
-	if err != nil {
-		return fmt.Errorf("error reading current user: %w", err)
+	curUser, curUserRes, err := usersApi.UserGet(c.withAuth(ctx))
+	if IsNotFound(err) {
+		return diag.Errorf("user not found")
 	}
 
-	if curUserRes.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("user not found")
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading current user: %w", err))
 	}
 
 	if curUserRes.StatusCode >= http.StatusInternalServerError {
-		return fmt.Errorf("internal server error fetching user")
+		return diag.Errorf("internal server error fetching user")
 	}
 
 	log.Printf("[DEBUG] Current User: %#v", curUser)
 
-	curUserEmails, err := httpClient.Get("2.0/user/emails")
+	emails, err := bitbucketapi.Paginate[UserEmail](ctx, &httpClient, "2.0/user/emails", nil)
 	if err != nil {
-		return err
-	}
-
-	emailBody, readerr := ioutil.ReadAll(curUserEmails.Body)
-	if readerr != nil {
-		return readerr
-	}
-
-	log.Printf("[DEBUG] Current User Emails Response JSON: %v", string(emailBody))
-
-	var emails PaginatedUserEmails
-
-	decodeerr := json.Unmarshal(emailBody, &emails)
-	if decodeerr != nil {
-		return decodeerr
+		return diag.FromErr(fmt.Errorf("error reading current user emails: %w", err))
 	}
 
 	log.Printf("[DEBUG] Current User Emails Response Decoded: %#v", emails)
@@ -109,7 +89,7 @@ func dataReadCurrentUser(d *schema.ResourceData, m interface{}) error {
 	d.Set("uuid", curUser.Uuid)
 	d.Set("username", curUser.Username)
 	d.Set("display_name", curUser.DisplayName)
-	d.Set("email", flattenUserEmails(emails.Values))
+	d.Set("email", flattenUserEmails(emails))
 
 	return nil
 }
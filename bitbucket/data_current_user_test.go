@@ -7,6 +7,7 @@ import (
 )
 
 func TestAccCurrentUser_basic(t *testing.T) {
+	testAccCassette(t)
 	dataSourceName := "data.bitbucket_current_user.test"
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
@@ -1,22 +1,24 @@
 package bitbucket
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
 func dataGroup() *schema.Resource {
 	return &schema.Resource{
-		Read: dataReadGroup,
+		ReadContext: dataReadGroup,
 
 		Schema: map[string]*schema.Schema{
 			"workspace": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"name": {
 				Type:     schema.TypeString,
@@ -42,30 +44,20 @@ func dataGroup() *schema.Resource {
 	}
 }
 
-func dataReadGroup(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
-
-	workspace := d.Get("workspace").(string)
-	slug := d.Get("slug").(string)
-
-	groupsReq, _ := client.Get(fmt.Sprintf("1.0/groups/%s/%s", workspace, slug))
-
-	if groupsReq.Body == nil {
-		return fmt.Errorf("error reading Group (%s): empty response", d.Id())
+func dataReadGroup(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	slug := d.Get("slug").(string)
 
-	var grp *UserGroup
-
-	body, readerr := ioutil.ReadAll(groupsReq.Body)
-	if readerr != nil {
-		return readerr
+	grp, err := lookupGroup(ctx, m, workspace, slug)
+	if IsNotFound(err) {
+		return diag.Errorf("group not found")
 	}
 
-	log.Printf("[DEBUG] Group Response JSON: %v", string(body))
-
-	decodeerr := json.Unmarshal(body, &grp)
-	if decodeerr != nil {
-		return decodeerr
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] Group Response Decoded: %#v", grp)
@@ -80,3 +72,14 @@ func dataReadGroup(d *schema.ResourceData, m interface{}) error {
 
 	return nil
 }
+
+// lookupGroup returns the group with slug of workspace, it is shared by the group data source and the resources that
+// grant groups access. Errors are returned as a *bitbucketapi.NotFoundError when the group doesn't exist.
+func lookupGroup(ctx context.Context, m interface{}, workspace, slug string) (*bitbucketapi.UserGroup, error) {
+	grp, err := m.(Clients).api.Groups.Get(ctx, workspace, slug)
+	if err != nil && !IsNotFound(err) {
+		return nil, fmt.Errorf("error reading Group (%s/%s): %w", workspace, slug, err)
+	}
+
+	return grp, err
+}
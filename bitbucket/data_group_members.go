@@ -1,22 +1,23 @@
 package bitbucket
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataGroupMembers() *schema.Resource {
 	return &schema.Resource{
-		Read: dataReadGroupMembers,
+		ReadContext: dataReadGroupMembers,
 
 		Schema: map[string]*schema.Schema{
 			"workspace": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"slug": {
 				Type:     schema.TypeString,
@@ -31,30 +32,18 @@ func dataGroupMembers() *schema.Resource {
 	}
 }
 
-func dataReadGroupMembers(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
-
-	workspace := d.Get("workspace").(string)
-	slug := d.Get("slug").(string)
-
-	groupsReq, _ := client.Get(fmt.Sprintf("1.0/groups/%s/%s/members", workspace, slug))
+func dataReadGroupMembers(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
-	if groupsReq.Body == nil {
-		return fmt.Errorf("error reading Group (%s): empty response", d.Id())
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	slug := d.Get("slug").(string)
 
-	var members []*UserGroupMembership
-
-	body, readerr := ioutil.ReadAll(groupsReq.Body)
-	if readerr != nil {
-		return readerr
-	}
-
-	log.Printf("[DEBUG] Group Membership Response JSON: %v", string(body))
-
-	decodeerr := json.Unmarshal(body, &members)
-	if decodeerr != nil {
-		return decodeerr
+	members, err := api.Groups.Members(ctx, workspace, slug)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Group Members (%s/%s): %w", workspace, slug, err))
 	}
 
 	log.Printf("[DEBUG] Group Membership Response Decoded: %#v", members)
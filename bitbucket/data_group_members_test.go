@@ -5,16 +5,16 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccDataGroupMembers_basic(t *testing.T) {
+	random := testAccCassette(t)
 	dataSourceName := "data.bitbucket_group_members.test"
 	groupResourceName := "bitbucket_group.test"
 
 	workspace := os.Getenv("BITBUCKET_TEAM")
-	rName := acctest.RandomWithPrefix("tf-test")
+	rName := random.name("tf-test")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
@@ -1,22 +1,24 @@
 package bitbucket
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
 func dataGroups() *schema.Resource {
 	return &schema.Resource{
-		Read: dataReadGroups,
+		ReadContext: dataReadGroups,
 
 		Schema: map[string]*schema.Schema{
 			"workspace": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"groups": {
 				Type:     schema.TypeSet,
@@ -46,29 +48,17 @@ func dataGroups() *schema.Resource {
 	}
 }
 
-func dataReadGroups(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
-
-	workspace := d.Get("workspace").(string)
+func dataReadGroups(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
-	groupsReq, _ := client.Get(fmt.Sprintf("1.0/groups/%s", workspace))
-
-	if groupsReq.Body == nil {
-		return fmt.Errorf("error reading Groups (%s): empty response", d.Id())
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	var grps []*UserGroup
-
-	body, readerr := ioutil.ReadAll(groupsReq.Body)
-	if readerr != nil {
-		return readerr
-	}
-
-	log.Printf("[DEBUG] Groups Response JSON: %v", string(body))
-
-	decodeerr := json.Unmarshal(body, &grps)
-	if decodeerr != nil {
-		return decodeerr
+	grps, err := api.Groups.List(ctx, workspace)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Groups (%s): %w", workspace, err))
 	}
 
 	log.Printf("[DEBUG] Groups Response Decoded: %#v", grps)
@@ -79,7 +69,7 @@ func dataReadGroups(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func flattenUserGroups(groups []*UserGroup) []interface{} {
+func flattenUserGroups(groups []*bitbucketapi.UserGroup) []interface{} {
 	if len(groups) == 0 {
 		return nil
 	}
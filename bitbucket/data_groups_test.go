@@ -9,6 +9,7 @@ import (
 )
 
 func TestAccDataGroups_basic(t *testing.T) {
+	testAccCassette(t)
 	dataSourceName := "data.bitbucket_groups.test"
 
 	workspace := os.Getenv("BITBUCKET_TEAM")
@@ -1,17 +1,19 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"net/http"
 
 	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
 func dataHookTypes() *schema.Resource {
 	return &schema.Resource{
-		Read: dataReadHookTypes,
+		ReadContext: dataReadHookTypes,
 
 		Schema: map[string]*schema.Schema{
 			"subject_type": {
@@ -46,26 +48,18 @@ func dataHookTypes() *schema.Resource {
 	}
 }
 
-func dataReadHookTypes(d *schema.ResourceData, m interface{}) error {
-	c := m.(Clients).genClient
-	webhooksApi := c.ApiClient.WebhooksApi
+func dataReadHookTypes(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).httpClient
 
 	subjectType := d.Get("subject_type").(string)
-	hookTypes, res, err := webhooksApi.HookEventsSubjectTypeGet(c.AuthContext, subjectType)
+	hookTypes, err := bitbucketapi.Paginate[bitbucket.HookEvent](ctx, &client,
+		bitbucketapi.Endpoint("2.0/hook_events/%s", subjectType), nil)
 	if err != nil {
-		return err
-	}
-
-	if res.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("user not found")
-	}
-
-	if res.StatusCode >= http.StatusInternalServerError {
-		return fmt.Errorf("internal server error fetching hook types")
+		return diag.FromErr(fmt.Errorf("error reading hook types (%s): %w", subjectType, err))
 	}
 
 	d.SetId(subjectType)
-	d.Set("hook_types", flattenHookTypes(hookTypes.Values))
+	d.Set("hook_types", flattenHookTypes(hookTypes))
 
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 )
 
 func TestAccHookTypes_basic(t *testing.T) {
+	testAccCassette(t)
 	dataSourceName := "data.bitbucket_hook_types.test"
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
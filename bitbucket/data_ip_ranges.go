@@ -1,15 +1,20 @@
 package bitbucket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// ipRangesURL publishes the ip ranges of atlassian's cloud products
+var ipRangesURL = "https://ip-ranges.atlassian.com/"
+
 type PaginatedIPRanges struct {
 	Items     []IPRange `json:"items,omitempty"`
 	SyncToken int       `json:"syncToken,omitempty"`
@@ -27,7 +32,7 @@ type IPRange struct {
 
 func dataIPRanges() *schema.Resource {
 	return &schema.Resource{
-		Read: dataReadIPRanges,
+		ReadContext: dataReadIPRanges,
 
 		Schema: map[string]*schema.Schema{
 			"ranges": {
@@ -73,20 +78,28 @@ func dataIPRanges() *schema.Resource {
 	}
 }
 
-func dataReadIPRanges(d *schema.ResourceData, m interface{}) error {
+func dataReadIPRanges(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// the ranges are public, the request goes through the shared transport but without the bitbucket credentials
+	httpClient := m.(Clients).httpClient.HTTPClient
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, ipRangesURL, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	req, err := http.Get("https://ip-ranges.atlassian.com/")
+	req, err := httpClient.Do(httpReq)
 	if err != nil {
-		return err
+		return diag.FromErr(fmt.Errorf("error reading IP Ranges: %w", err))
 	}
+	defer req.Body.Close()
 
 	if req.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("IP whitelist not found")
+		return diag.Errorf("IP whitelist not found")
 	}
 
 	body, readerr := ioutil.ReadAll(req.Body)
 	if readerr != nil {
-		return readerr
+		return diag.FromErr(readerr)
 	}
 
 	log.Printf("[DEBUG] IP Ranges Response JSON: %v", string(body))
@@ -95,7 +108,7 @@ func dataReadIPRanges(d *schema.ResourceData, m interface{}) error {
 
 	decodeerr := json.Unmarshal(body, &pageIpRanges)
 	if decodeerr != nil {
-		return decodeerr
+		return diag.FromErr(decodeerr)
 	}
 
 	log.Printf("[DEBUG] IP Ranges Decoded: %#v", pageIpRanges)
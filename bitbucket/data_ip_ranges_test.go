@@ -7,6 +7,7 @@ import (
 )
 
 func TestAccIPRanges_basic(t *testing.T) {
+	testAccCassette(t)
 	dataSourceName := "data.bitbucket_ip_ranges.test"
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
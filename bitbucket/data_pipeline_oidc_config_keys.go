@@ -1,22 +1,25 @@
 package bitbucket
 
 import (
-	"fmt"
+	"context"
 	"io/ioutil"
 	"log"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
 func dataPipelineOidcConfigKeys() *schema.Resource {
 	return &schema.Resource{
-		Read: dataReadPipelineOidcConfigKeys,
+		ReadContext: dataReadPipelineOidcConfigKeys,
 
 		Schema: map[string]*schema.Schema{
 			"workspace": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"keys": {
 				Type:      schema.TypeString,
@@ -27,26 +30,29 @@ func dataPipelineOidcConfigKeys() *schema.Resource {
 	}
 }
 
-func dataReadPipelineOidcConfigKeys(d *schema.ResourceData, m interface{}) error {
+func dataReadPipelineOidcConfigKeys(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).httpClient
 
-	workspace := d.Get("workspace").(string)
-	req, err := c.Get(fmt.Sprintf("2.0/workspaces/%s/pipelines-config/identity/oidc/keys.json", workspace))
+	workspace, err := resolveWorkspace(d, m, "workspace")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	req, err := c.Get(ctx, bitbucketapi.Endpoint("2.0/workspaces/%s/pipelines-config/identity/oidc/keys.json", workspace))
+	if IsNotFound(err) {
+		return diag.Errorf("pipeline oidc configuration not found")
 	}
 
-	if req.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("user not found")
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	if req.StatusCode >= http.StatusInternalServerError {
-		return fmt.Errorf("internal server error fetching user")
+		return diag.Errorf("internal server error fetching user")
 	}
 
 	body, readerr := ioutil.ReadAll(req.Body)
 	if readerr != nil {
-		return readerr
+		return diag.FromErr(readerr)
 	}
 
 	log.Printf("[DEBUG] Pipeline Oidc Config Keys Response JSON: %v", string(body))
@@ -9,6 +9,7 @@ import (
 )
 
 func TestAccPipelineOidcConfigKeys_basic(t *testing.T) {
+	testAccCassette(t)
 	dataSourceName := "data.bitbucket_pipeline_oidc_config_keys.test"
 	workspace := os.Getenv("BITBUCKET_TEAM")
 	resource.Test(t, resource.TestCase{
@@ -1,17 +1,20 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 
+	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataUser() *schema.Resource {
 	return &schema.Resource{
-		Read: dataReadUser,
+		ReadContext: dataReadUser,
 
 		Schema: map[string]*schema.Schema{
 			"username": {
@@ -31,26 +34,20 @@ func dataUser() *schema.Resource {
 	}
 }
 
-func dataReadUser(d *schema.ResourceData, m interface{}) error {
-	c := m.(Clients).genClient
-	usersApi := c.ApiClient.UsersApi
+func dataReadUser(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var selectedUser string
 
 	if v, ok := d.GetOk("uuid"); ok && v.(string) != "" {
 		selectedUser = v.(string)
 	}
 
-	user, userRes, err := usersApi.UsersSelectedUserGet(c.AuthContext, selectedUser)
-	if err != nil {
-		return fmt.Errorf("error reading User (%s): %w", selectedUser, err)
-	}
-
-	if userRes.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("user not found")
+	user, err := lookupUser(ctx, m, selectedUser)
+	if IsNotFound(err) {
+		return diag.Errorf("user not found")
 	}
 
-	if userRes.StatusCode >= http.StatusInternalServerError {
-		return fmt.Errorf("internal server error fetching user")
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] User: %#v", user)
@@ -62,3 +59,25 @@ func dataReadUser(d *schema.ResourceData, m interface{}) error {
 
 	return nil
 }
+
+// lookupUser returns the user with uuid, it is shared by the user data source and the resources that grant users
+// access. Errors are returned as a *bitbucketapi.NotFoundError when the user doesn't exist.
+func lookupUser(ctx context.Context, m interface{}, uuid string) (*bitbucket.Account, error) {
+	c := m.(Clients).genClient
+	usersApi := c.ApiClient.UsersApi
+
+	user, userRes, err := usersApi.UsersSelectedUserGet(c.withAuth(ctx), uuid)
+	if IsNotFound(err) {
+		return nil, err
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading User (%s): %w", uuid, err)
+	}
+
+	if userRes.StatusCode >= http.StatusInternalServerError {
+		return nil, fmt.Errorf("internal server error fetching user")
+	}
+
+	return &user, nil
+}
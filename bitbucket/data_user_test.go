@@ -7,6 +7,7 @@ import (
 )
 
 func TestAccUser_uuid(t *testing.T) {
+	testAccCassette(t)
 	dataSourceName := "data.bitbucket_user.test"
 	currUserDataSource := "data.bitbucket_current_user.test"
 
@@ -1,20 +1,22 @@
 package bitbucket
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataWorkspace() *schema.Resource {
 	return &schema.Resource{
-		Read: dataReadWorkspace,
+		ReadContext: dataReadWorkspace,
 
 		Schema: map[string]*schema.Schema{
 			"workspace": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"slug": {
 				Type:     schema.TypeString,
@@ -32,23 +34,26 @@ func dataWorkspace() *schema.Resource {
 	}
 }
 
-func dataReadWorkspace(d *schema.ResourceData, m interface{}) error {
+func dataReadWorkspace(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 
 	workspaceApi := c.ApiClient.WorkspacesApi
 
-	workspace := d.Get("workspace").(string)
-	workspaceReq, res, err := workspaceApi.WorkspacesWorkspaceGet(c.AuthContext, workspace)
+	workspace, err := resolveWorkspace(d, m, "workspace")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	workspaceReq, res, err := workspaceApi.WorkspacesWorkspaceGet(c.withAuth(ctx), workspace)
+	if IsNotFound(err) {
+		return diag.Errorf("workspace not found")
 	}
 
-	if res.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("workspace not found")
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	if res.StatusCode >= http.StatusInternalServerError {
-		return fmt.Errorf("internal server error fetching workspace")
+		return diag.Errorf("internal server error fetching workspace")
 	}
 
 	d.SetId(workspaceReq.Uuid)
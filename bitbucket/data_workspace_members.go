@@ -1,21 +1,23 @@
 package bitbucket
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 
 	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
 func dataWorkspaceMembers() *schema.Resource {
 	return &schema.Resource{
-		Read: dataReadWorkspaceMembers,
+		ReadContext: dataReadWorkspaceMembers,
 
 		Schema: map[string]*schema.Schema{
 			"workspace": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"members": {
 				Type:     schema.TypeSet,
@@ -26,43 +28,22 @@ func dataWorkspaceMembers() *schema.Resource {
 	}
 }
 
-func dataReadWorkspaceMembers(d *schema.ResourceData, m interface{}) error {
+func dataReadWorkspaceMembers(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(Clients).httpClient
 
-	workspace := d.Get("workspace").(string)
-	resourceURL := fmt.Sprintf("2.0/workspaces/%s/members", workspace)
-
-	_, err := client.Get(resourceURL)
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	memberships, err := bitbucketapi.Paginate[bitbucket.WorkspaceMembership](ctx, &client,
+		bitbucketapi.Endpoint("2.0/workspaces/%s/members", workspace), &bitbucketapi.PaginationOptions{PageLen: 100})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	var paginatedMemberships bitbucket.PaginatedWorkspaceMemberships
 	var members []string
-
-	for {
-		membersRes, err := client.Get(resourceURL)
-		if err != nil {
-			return err
-		}
-
-		decoder := json.NewDecoder(membersRes.Body)
-		err = decoder.Decode(&paginatedMemberships)
-		if err != nil {
-			return err
-		}
-
-		for _, member := range paginatedMemberships.Values {
-			members = append(members, member.User.Uuid)
-		}
-
-		if paginatedMemberships.Next != "" {
-			nextPage := paginatedMemberships.Page + 1
-			resourceURL = fmt.Sprintf("2.0/workspaces/%s/members?page=%d", workspace, nextPage)
-			paginatedMemberships = bitbucket.PaginatedWorkspaceMemberships{}
-		} else {
-			break
-		}
+	for _, member := range memberships {
+		members = append(members, member.User.Uuid)
 	}
 
 	d.SetId(workspace)
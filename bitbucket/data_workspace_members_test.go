@@ -9,6 +9,7 @@ import (
 )
 
 func TestAccWorkspaceMembers_basic(t *testing.T) {
+	testAccCassette(t)
 	dataSourceName := "data.bitbucket_workspace_members.test"
 	workspace := os.Getenv("BITBUCKET_TEAM")
 	resource.Test(t, resource.TestCase{
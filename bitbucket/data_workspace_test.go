@@ -9,6 +9,7 @@ import (
 )
 
 func TestAccWorkspace_basic(t *testing.T) {
+	testAccCassette(t)
 	dataSourceName := "data.bitbucket_workspace.test"
 	workspace := os.Getenv("BITBUCKET_TEAM")
 	resource.Test(t, resource.TestCase{
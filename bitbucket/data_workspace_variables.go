@@ -0,0 +1,78 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataWorkspaceVariables() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataReadWorkspaceVariables,
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"variables": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"secured": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataReadWorkspaceVariables(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	variables, err := api.WorkspaceVariables.List(ctx, workspace)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Workspace Variables (%s): %w", workspace, err))
+	}
+
+	// bitbucket leaves out the values of secured variables
+	tfList := make([]interface{}, 0, len(variables))
+	for _, variable := range variables {
+		tfList = append(tfList, map[string]interface{}{
+			"uuid":    variable.Uuid,
+			"key":     variable.Key,
+			"value":   variable.Value,
+			"secured": variable.Secured,
+		})
+	}
+
+	d.SetId(workspace)
+	d.Set("workspace", workspace)
+	d.Set("variables", tfList)
+
+	return nil
+}
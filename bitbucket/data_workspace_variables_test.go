@@ -0,0 +1,77 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccDataWorkspaceVariables_basic(t *testing.T) {
+	random := testAccCassette(t)
+	dataSourceName := "data.bitbucket_workspace_variables.test"
+	workspace := os.Getenv("BITBUCKET_TEAM")
+	rName := random.stringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketWorkspaceVariablesConfig(workspace, rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "workspace", workspace),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "variables.*", map[string]string{
+						"key":     "TF_TEST_" + rName,
+						"value":   "test-val",
+						"secured": "false",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccBitbucketWorkspaceVariablesConfig(workspace, rName string) string {
+	return fmt.Sprintf(`
+resource "bitbucket_workspace_variable" "test" {
+  workspace = %[1]q
+  key       = "TF_TEST_%[2]s"
+  value     = "test-val"
+}
+
+data "bitbucket_workspace_variables" "test" {
+  workspace = bitbucket_workspace_variable.test.workspace
+}
+`, workspace, rName)
+}
+
+func TestDataWorkspaceVariables_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seed("2.0/workspaces/fake-workspace/pipelines-config/variables/{plain}", map[string]interface{}{
+		"uuid": "{plain}", "key": "PLAIN", "value": "visible", "secured": false,
+	})
+	f.seed("2.0/workspaces/fake-workspace/pipelines-config/variables/{hidden}", map[string]interface{}{
+		"uuid": "{hidden}", "key": "HIDDEN", "value": "secret", "secured": true,
+	})
+	f.pageLen = 1
+
+	d := schema.TestResourceDataRaw(t, dataWorkspaceVariables().Schema, map[string]interface{}{})
+	if diags := dataReadWorkspaceVariables(context.Background(), d, f.providerMeta(t, nil)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "fake-workspace" || d.Get("variables.#").(int) != 2 {
+		t.Fatalf("expected both variables of fake-workspace, got %#v", d.Get("variables"))
+	}
+	if d.Get("variables.0.key") != "PLAIN" || d.Get("variables.0.value") != "visible" {
+		t.Errorf("expected the value of the plain variable, got %#v", d.Get("variables.0"))
+	}
+	if d.Get("variables.1.key") != "HIDDEN" || d.Get("variables.1.value") != "" || d.Get("variables.1.secured") != true {
+		t.Errorf("expected the value of the secured variable to be left out, got %#v", d.Get("variables.1"))
+	}
+}
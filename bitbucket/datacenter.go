@@ -0,0 +1,111 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// FlavorCloud talks to bitbucket.org
+	FlavorCloud = "cloud"
+	// FlavorDataCenter talks to a self-hosted Bitbucket Data Center or Server
+	FlavorDataCenter = "datacenter"
+)
+
+type crudFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+// dataCenterResource implements a resource against Bitbucket Data Center. Functions that are nil fall back to the
+// cloud implementation.
+type dataCenterResource struct {
+	create crudFunc
+	read   crudFunc
+	update crudFunc
+	delete crudFunc
+}
+
+// dataCenterResources are the resources that support Bitbucket Data Center
+var dataCenterResources = map[string]dataCenterResource{
+	"bitbucket_project": {
+		create: resourceProjectDataCenterCreate,
+		read:   resourceProjectDataCenterRead,
+		update: resourceProjectDataCenterUpdate,
+		delete: resourceProjectDataCenterDelete,
+	},
+	"bitbucket_repository": {
+		create: resourceRepositoryDataCenterCreate,
+		read:   resourceRepositoryDataCenterRead,
+		update: resourceRepositoryDataCenterUpdate,
+		delete: resourceRepositoryDataCenterDelete,
+	},
+	"bitbucket_hook": {
+		create: resourceHookDataCenterCreate,
+		read:   resourceHookDataCenterRead,
+		update: resourceHookDataCenterUpdate,
+		delete: resourceHookDataCenterDelete,
+	},
+	"bitbucket_branch_restriction": {
+		create: resourceBranchRestrictionsDataCenterCreate,
+		read:   resourceBranchRestrictionsDataCenterRead,
+		update: resourceBranchRestrictionsDataCenterUpdate,
+		delete: resourceBranchRestrictionsDataCenterDelete,
+	},
+	"bitbucket_default_reviewers": {
+		create: resourceDefaultReviewersDataCenterCreate,
+		read:   resourceDefaultReviewersDataCenterRead,
+		update: resourceDefaultReviewersDataCenterUpdate,
+		delete: resourceDefaultReviewersDataCenterDelete,
+	},
+}
+
+// isDataCenter reports whether the provider is configured for Bitbucket Data Center
+func (c Clients) isDataCenter() bool {
+	return c.flavor == FlavorDataCenter
+}
+
+// withFlavor routes the functions of r to the Data Center implementation registered for name when the provider is
+// configured for Data Center. Resources and data sources without one fail with an error instead of sending cloud
+// requests to a Data Center server.
+func withFlavor(name string, r *schema.Resource) *schema.Resource {
+	dc, supported := dataCenterResources[name]
+
+	wrap := func(cloud, dataCenter crudFunc) crudFunc {
+		if cloud == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			if !m.(Clients).isDataCenter() {
+				return cloud(ctx, d, m)
+			}
+			if !supported {
+				return diag.Errorf("%s is not supported on Bitbucket Data Center", name)
+			}
+			if dataCenter == nil {
+				return cloud(ctx, d, m)
+			}
+			return dataCenter(ctx, d, m)
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext, dc.create)
+	r.ReadContext = wrap(r.ReadContext, dc.read)
+	r.UpdateContext = wrap(r.UpdateContext, dc.update)
+	r.DeleteContext = wrap(r.DeleteContext, dc.delete)
+
+	return r
+}
+
+// dataCenterRepoId splits the id of an object that belongs to a Data Center repository into the project key, the
+// repository slug and the id of the object
+func dataCenterRepoId(id string) (string, string, string, error) {
+	parts := strings.Split(id, "/")
+
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("unexpected format of ID (%q), expected PROJECT-KEY/REPO-SLUG/ID", id)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
@@ -0,0 +1,271 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
+)
+
+// DataCenterProject is a project of Bitbucket Data Center
+type DataCenterProject struct {
+	ID          int    `json:"id,omitempty"`
+	Key         string `json:"key"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Public      bool   `json:"public"`
+}
+
+// DataCenterLink is a named link of a Bitbucket Data Center object, e.g. a clone url
+type DataCenterLink struct {
+	Href string `json:"href"`
+	Name string `json:"name,omitempty"`
+}
+
+// DataCenterLinks are the links of a Bitbucket Data Center repository
+type DataCenterLinks struct {
+	Clone []DataCenterLink `json:"clone,omitempty"`
+	Self  []DataCenterLink `json:"self,omitempty"`
+}
+
+// DataCenterRepository is a repository of Bitbucket Data Center
+type DataCenterRepository struct {
+	ID          int                `json:"id,omitempty"`
+	Slug        string             `json:"slug,omitempty"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	ScmID       string             `json:"scmId,omitempty"`
+	Forkable    bool               `json:"forkable"`
+	Public      bool               `json:"public"`
+	Project     *DataCenterProject `json:"project,omitempty"`
+	Links       *DataCenterLinks   `json:"links,omitempty"`
+}
+
+// DataCenterWebhook is a repository webhook of Bitbucket Data Center
+type DataCenterWebhook struct {
+	ID                      int      `json:"id,omitempty"`
+	Name                    string   `json:"name"`
+	URL                     string   `json:"url"`
+	Active                  bool     `json:"active"`
+	Events                  []string `json:"events"`
+	SslVerificationRequired bool     `json:"sslVerificationRequired"`
+}
+
+// DataCenterUser is a user of Bitbucket Data Center
+type DataCenterUser struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Slug        string `json:"slug,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// DataCenterMatcherType is the kind of refs a DataCenterMatcher matches, e.g. BRANCH or PATTERN
+type DataCenterMatcherType struct {
+	ID string `json:"id"`
+}
+
+// DataCenterMatcher selects the refs a branch permission or default reviewer condition applies to
+type DataCenterMatcher struct {
+	ID   string                `json:"id"`
+	Type DataCenterMatcherType `json:"type"`
+}
+
+// DataCenterRestriction is a branch permission of Bitbucket Data Center
+type DataCenterRestriction struct {
+	ID      int               `json:"id,omitempty"`
+	Type    string            `json:"type"`
+	Matcher DataCenterMatcher `json:"matcher"`
+	Users   []DataCenterUser  `json:"users,omitempty"`
+	Groups  []string          `json:"groups,omitempty"`
+}
+
+// dataCenterRestrictionRequest is the body to create a branch permission, users are referenced by name
+type dataCenterRestrictionRequest struct {
+	Type       string            `json:"type"`
+	Matcher    DataCenterMatcher `json:"matcher"`
+	Users      []string          `json:"users"`
+	Groups     []string          `json:"groups"`
+	AccessKeys []int             `json:"accessKeys"`
+}
+
+// DataCenterReviewerCondition requires reviewers on pull requests between the refs its matchers select
+type DataCenterReviewerCondition struct {
+	ID                int               `json:"id,omitempty"`
+	SourceMatcher     DataCenterMatcher `json:"sourceMatcher"`
+	TargetMatcher     DataCenterMatcher `json:"targetMatcher"`
+	Reviewers         []DataCenterUser  `json:"reviewers"`
+	RequiredApprovals int               `json:"requiredApprovals"`
+}
+
+// dataCenterAnyRef matches every branch and tag
+var dataCenterAnyRef = DataCenterMatcher{ID: "ANY_REF_MATCHER_ID", Type: DataCenterMatcherType{ID: "ANY_REF"}}
+
+// DataCenterClient talks to the REST api of Bitbucket Data Center. It shares authentication and transport with
+// the internal Client it is built on.
+type DataCenterClient struct {
+	client *Client
+}
+
+func projectPath(projectKey string) string {
+	return fmt.Sprintf("rest/api/1.0/projects/%s", url.PathEscape(projectKey))
+}
+
+func repositoryPath(projectKey, repoSlug string) string {
+	return fmt.Sprintf("%s/repos/%s", projectPath(projectKey), url.PathEscape(repoSlug))
+}
+
+func (c *DataCenterClient) do(ctx context.Context, method, endpoint string, in, out interface{}) error {
+	return bitbucketapi.DoJSON(ctx, c.client, method, endpoint, in, out)
+}
+
+// CreateProject creates project
+func (c *DataCenterClient) CreateProject(ctx context.Context, project *DataCenterProject) (*DataCenterProject, error) {
+	var created DataCenterProject
+	err := c.do(ctx, http.MethodPost, "rest/api/1.0/projects", project, &created)
+	return &created, err
+}
+
+// GetProject returns the project with projectKey
+func (c *DataCenterClient) GetProject(ctx context.Context, projectKey string) (*DataCenterProject, error) {
+	var project DataCenterProject
+	err := c.do(ctx, http.MethodGet, projectPath(projectKey), nil, &project)
+	return &project, err
+}
+
+// UpdateProject updates the project with projectKey, changing the key of project moves it
+func (c *DataCenterClient) UpdateProject(ctx context.Context, projectKey string, project *DataCenterProject) (*DataCenterProject, error) {
+	var updated DataCenterProject
+	err := c.do(ctx, http.MethodPut, projectPath(projectKey), project, &updated)
+	return &updated, err
+}
+
+// DeleteProject deletes the project with projectKey, it has to be empty
+func (c *DataCenterClient) DeleteProject(ctx context.Context, projectKey string) error {
+	return c.do(ctx, http.MethodDelete, projectPath(projectKey), nil, nil)
+}
+
+// CreateRepository creates repo in the project with projectKey
+func (c *DataCenterClient) CreateRepository(ctx context.Context, projectKey string, repo *DataCenterRepository) (*DataCenterRepository, error) {
+	var created DataCenterRepository
+	err := c.do(ctx, http.MethodPost, projectPath(projectKey)+"/repos", repo, &created)
+	return &created, err
+}
+
+// GetRepository returns the repository repoSlug of the project with projectKey
+func (c *DataCenterClient) GetRepository(ctx context.Context, projectKey, repoSlug string) (*DataCenterRepository, error) {
+	var repo DataCenterRepository
+	err := c.do(ctx, http.MethodGet, repositoryPath(projectKey, repoSlug), nil, &repo)
+	return &repo, err
+}
+
+// UpdateRepository updates the repository repoSlug, renaming it changes its slug
+func (c *DataCenterClient) UpdateRepository(ctx context.Context, projectKey, repoSlug string, repo *DataCenterRepository) (*DataCenterRepository, error) {
+	var updated DataCenterRepository
+	err := c.do(ctx, http.MethodPut, repositoryPath(projectKey, repoSlug), repo, &updated)
+	return &updated, err
+}
+
+// DeleteRepository schedules the repository repoSlug for deletion
+func (c *DataCenterClient) DeleteRepository(ctx context.Context, projectKey, repoSlug string) error {
+	return c.do(ctx, http.MethodDelete, repositoryPath(projectKey, repoSlug), nil, nil)
+}
+
+// CreateWebhook adds hook to the repository repoSlug
+func (c *DataCenterClient) CreateWebhook(ctx context.Context, projectKey, repoSlug string, hook *DataCenterWebhook) (*DataCenterWebhook, error) {
+	var created DataCenterWebhook
+	err := c.do(ctx, http.MethodPost, repositoryPath(projectKey, repoSlug)+"/webhooks", hook, &created)
+	return &created, err
+}
+
+// GetWebhook returns the webhook with id of the repository repoSlug
+func (c *DataCenterClient) GetWebhook(ctx context.Context, projectKey, repoSlug, id string) (*DataCenterWebhook, error) {
+	var hook DataCenterWebhook
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("%s/webhooks/%s", repositoryPath(projectKey, repoSlug), url.PathEscape(id)), nil, &hook)
+	return &hook, err
+}
+
+// UpdateWebhook replaces the webhook with id of the repository repoSlug
+func (c *DataCenterClient) UpdateWebhook(ctx context.Context, projectKey, repoSlug, id string, hook *DataCenterWebhook) (*DataCenterWebhook, error) {
+	var updated DataCenterWebhook
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("%s/webhooks/%s", repositoryPath(projectKey, repoSlug), url.PathEscape(id)), hook, &updated)
+	return &updated, err
+}
+
+// DeleteWebhook removes the webhook with id from the repository repoSlug
+func (c *DataCenterClient) DeleteWebhook(ctx context.Context, projectKey, repoSlug, id string) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("%s/webhooks/%s", repositoryPath(projectKey, repoSlug), url.PathEscape(id)), nil, nil)
+}
+
+func restrictionsPath(projectKey, repoSlug string) string {
+	return fmt.Sprintf("rest/branch-permissions/2.0/projects/%s/repos/%s/restrictions", url.PathEscape(projectKey), url.PathEscape(repoSlug))
+}
+
+// CreateRestriction adds a branch permission to the repository repoSlug
+func (c *DataCenterClient) CreateRestriction(ctx context.Context, projectKey, repoSlug string, restriction *DataCenterRestriction) (*DataCenterRestriction, error) {
+	request := &dataCenterRestrictionRequest{
+		Type:       restriction.Type,
+		Matcher:    restriction.Matcher,
+		Users:      make([]string, 0, len(restriction.Users)),
+		Groups:     restriction.Groups,
+		AccessKeys: []int{},
+	}
+	for _, user := range restriction.Users {
+		request.Users = append(request.Users, user.Name)
+	}
+	if request.Groups == nil {
+		request.Groups = []string{}
+	}
+
+	var created DataCenterRestriction
+	err := c.do(ctx, http.MethodPost, restrictionsPath(projectKey, repoSlug), request, &created)
+	return &created, err
+}
+
+// GetRestriction returns the branch permission with id of the repository repoSlug
+func (c *DataCenterClient) GetRestriction(ctx context.Context, projectKey, repoSlug, id string) (*DataCenterRestriction, error) {
+	var restriction DataCenterRestriction
+	err := c.do(ctx, http.MethodGet, restrictionsPath(projectKey, repoSlug)+"/"+url.PathEscape(id), nil, &restriction)
+	return &restriction, err
+}
+
+// DeleteRestriction removes the branch permission with id from the repository repoSlug
+func (c *DataCenterClient) DeleteRestriction(ctx context.Context, projectKey, repoSlug, id string) error {
+	return c.do(ctx, http.MethodDelete, restrictionsPath(projectKey, repoSlug)+"/"+url.PathEscape(id), nil, nil)
+}
+
+func reviewerConditionsPath(projectKey, repoSlug string) string {
+	return fmt.Sprintf("rest/default-reviewers/1.0/projects/%s/repos/%s", url.PathEscape(projectKey), url.PathEscape(repoSlug))
+}
+
+// CreateReviewerCondition adds a default reviewer condition to the repository repoSlug
+func (c *DataCenterClient) CreateReviewerCondition(ctx context.Context, projectKey, repoSlug string, condition *DataCenterReviewerCondition) (*DataCenterReviewerCondition, error) {
+	var created DataCenterReviewerCondition
+	err := c.do(ctx, http.MethodPost, reviewerConditionsPath(projectKey, repoSlug)+"/condition", condition, &created)
+	return &created, err
+}
+
+// ListReviewerConditions returns the default reviewer conditions of the repository repoSlug
+func (c *DataCenterClient) ListReviewerConditions(ctx context.Context, projectKey, repoSlug string) ([]DataCenterReviewerCondition, error) {
+	return bitbucketapi.Paginate[DataCenterReviewerCondition](ctx, c.client, reviewerConditionsPath(projectKey, repoSlug)+"/conditions", nil)
+}
+
+// UpdateReviewerCondition replaces the default reviewer condition with id of the repository repoSlug
+func (c *DataCenterClient) UpdateReviewerCondition(ctx context.Context, projectKey, repoSlug, id string, condition *DataCenterReviewerCondition) (*DataCenterReviewerCondition, error) {
+	var updated DataCenterReviewerCondition
+	err := c.do(ctx, http.MethodPut, reviewerConditionsPath(projectKey, repoSlug)+"/condition/"+url.PathEscape(id), condition, &updated)
+	return &updated, err
+}
+
+// DeleteReviewerCondition removes the default reviewer condition with id from the repository repoSlug
+func (c *DataCenterClient) DeleteReviewerCondition(ctx context.Context, projectKey, repoSlug, id string) error {
+	return c.do(ctx, http.MethodDelete, reviewerConditionsPath(projectKey, repoSlug)+"/condition/"+url.PathEscape(id), nil, nil)
+}
+
+// GetUser returns the user with userSlug
+func (c *DataCenterClient) GetUser(ctx context.Context, userSlug string) (*DataCenterUser, error) {
+	var user DataCenterUser
+	err := c.do(ctx, http.MethodGet, "rest/api/1.0/users/"+url.PathEscape(userSlug), nil, &user)
+	return &user, err
+}
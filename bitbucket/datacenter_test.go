@@ -0,0 +1,46 @@
+package bitbucket
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testDataCenterProvider configures the provider of offline tests for Bitbucket Data Center
+var testDataCenterProvider = map[string]interface{}{
+	"flavor":                FlavorDataCenter,
+	"username":              nil,
+	"password":              nil,
+	"personal_access_token": "fake-token",
+	"workspace":             "PROJ",
+}
+
+func TestDataCenterUnsupportedResource(t *testing.T) {
+	f := newFakeBitbucket(t)
+	meta := f.providerMeta(t, testDataCenterProvider)
+	r := Provider().ResourcesMap["bitbucket_group"]
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"name": "group"})
+	diff, err := r.Diff(context.Background(), nil, config, meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+
+	_, diags := r.Apply(context.Background(), nil, diff, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "bitbucket_group is not supported on Bitbucket Data Center") {
+		t.Errorf("expected bitbucket_group to be rejected, got %v", diags)
+	}
+}
+
+func TestDataCenterRequiresAPIURL(t *testing.T) {
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"flavor":                FlavorDataCenter,
+		"personal_access_token": "fake-token",
+	}))
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "api_url must point at the Bitbucket Data Center server") {
+		t.Errorf("expected a missing api_url to be rejected, got %v", diags)
+	}
+}
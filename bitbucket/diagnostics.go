@@ -0,0 +1,76 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// apiDiagnostics is diag.FromErr for the errors of either client. The message and detail of an error returned by
+// bitbucket become the diagnostic of err, each invalid field becomes a diagnostic of its own that is attached to the
+// attribute of d with the same name, so terraform shows it against the configuration.
+func apiDiagnostics(d *schema.ResourceData, err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	apiErr, ok := AsError(err)
+	if !ok {
+		return diag.FromErr(err)
+	}
+
+	summary := err.Error()
+	var details []string
+	for _, s := range []string{apiErr.message(), apiErr.APIError.Detail} {
+		if s != "" && !strings.Contains(summary, s) {
+			details = append(details, s)
+		}
+	}
+
+	diags := diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   strings.Join(details, "\n"),
+	}}
+
+	for _, field := range apiErr.fieldErrors() {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("%s: %s", field.field, field.message),
+			AttributePath: attributePath(d, field.field),
+		})
+	}
+
+	return diags
+}
+
+// attributePath returns the path of the attribute of d a field of the api refers to, nested fields are separated by
+// dots. It is nil when d has no such attribute.
+func attributePath(d *schema.ResourceData, field string) cty.Path {
+	parts := strings.Split(field, ".")
+
+	ty := d.GetRawConfig().Type()
+	if !ty.IsObjectType() || !ty.HasAttribute(parts[0]) {
+		return nil
+	}
+
+	path := cty.GetAttrPath(parts[0])
+	ty = ty.AttributeType(parts[0])
+	for _, part := range parts[1:] {
+		// blocks with a single element, such as the settings of the branching model, are lists in the schema
+		if ty.IsListType() {
+			path = path.IndexInt(0)
+			ty = ty.ElementType()
+		}
+		if !ty.IsObjectType() || !ty.HasAttribute(part) {
+			break
+		}
+		path = path.GetAttr(part)
+		ty = ty.AttributeType(part)
+	}
+
+	return path
+}
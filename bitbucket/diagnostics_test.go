@@ -0,0 +1,68 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestAPIDiagnostics(t *testing.T) {
+	d := resourceBranchingModel().Data(nil)
+
+	var apiErr Error
+	if err := json.Unmarshal([]byte(`{"type":"error","error":{"message":"Bad request","detail":"invalid settings",`+
+		`"fields":{"development.name":["Branch does not exist"],"repository":"Unknown repository","unknown":["Ignored"]}}}`), &apiErr); err != nil {
+		t.Fatal(err)
+	}
+	apiErr.StatusCode = 400
+	apiErr.Endpoint = "2.0/repositories/ws/repo/branching-model/settings"
+
+	diags := apiDiagnostics(d, fmt.Errorf("error updating Branching Model: %w", apiErr))
+	if len(diags) != 4 {
+		t.Fatalf("expected a diagnostic per field, got %#v", diags)
+	}
+
+	if diags[0].Summary != "error updating Branching Model: API Error: 400 2.0/repositories/ws/repo/branching-model/settings Bad request: invalid settings, "+
+		"development.name: Branch does not exist, repository: Unknown repository, unknown: Ignored" {
+		t.Errorf("unexpected summary %q", diags[0].Summary)
+	}
+
+	expected := []struct {
+		summary string
+		path    cty.Path
+	}{
+		{"development.name: Branch does not exist", cty.GetAttrPath("development").IndexInt(0).GetAttr("name")},
+		{"repository: Unknown repository", cty.GetAttrPath("repository")},
+		{"unknown: Ignored", nil},
+	}
+	for i, e := range expected {
+		if diags[i+1].Summary != e.summary || !diags[i+1].AttributePath.Equals(e.path) {
+			t.Errorf("expected %q at %#v, got %q at %#v", e.summary, e.path, diags[i+1].Summary, diags[i+1].AttributePath)
+		}
+	}
+}
+
+func TestAPIDiagnostics_dataCenter(t *testing.T) {
+	d := resourceRepository().Data(nil)
+
+	var apiErr Error
+	if err := json.Unmarshal([]byte(`{"errors":[{"context":"name","message":"This repository name is already taken."},`+
+		`{"message":"Repository could not be created."}]}`), &apiErr); err != nil {
+		t.Fatal(err)
+	}
+
+	diags := apiDiagnostics(d, apiErr)
+	if len(diags) != 2 || diags[1].Summary != "name: This repository name is already taken." || !diags[1].AttributePath.Equals(cty.GetAttrPath("name")) {
+		t.Errorf("expected the error to be attached to name, got %#v", diags)
+	}
+}
+
+func TestAPIDiagnostics_otherErrors(t *testing.T) {
+	diags := apiDiagnostics(resourceRepository().Data(nil), errors.New("boom"))
+	if len(diags) != 1 || diags[0].Summary != "boom" || diags[0].AttributePath != nil {
+		t.Errorf("expected a plain diagnostic, got %#v", diags)
+	}
+}
@@ -0,0 +1,958 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
+)
+
+// fakeCollection is a list endpoint of the fake api, posting to it creates a new object below it
+type fakeCollection struct {
+	pattern *regexp.Regexp
+	// idField is the attribute the generated id is stored in
+	idField string
+	// idFrom names the attribute of the request the id is derived from instead of generating one
+	idFrom string
+	// form endpoints take url encoded forms instead of json
+	form bool
+	// numeric ids are used by the endpoints that identify objects by an integer, e.g. branch restrictions
+	numeric bool
+	// escaped ids are path escaped in the path of the object, e.g. branch names that contain slashes
+	escaped bool
+}
+
+var fakeCollections = []fakeCollection{
+	{pattern: regexp.MustCompile(`^1\.0/groups/[^/]+$`), idField: "slug", idFrom: "name", form: true},
+	{pattern: regexp.MustCompile(`^2\.0/workspaces/[^/]+/hooks$`), idField: "uuid"},
+	{pattern: regexp.MustCompile(`^2\.0/workspaces/[^/]+/pipelines-config/variables$`), idField: "uuid"},
+	{pattern: regexp.MustCompile(`^2\.0/workspaces/[^/]+/projects$`), idField: "key", idFrom: "key"},
+	{pattern: regexp.MustCompile(`^2\.0/users/[^/]+/ssh-keys$`), idField: "uuid"},
+	{pattern: regexp.MustCompile(`^2\.0/repositories/[^/]+/[^/]+/hooks$`), idField: "uuid"},
+	{pattern: regexp.MustCompile(`^2\.0/repositories/[^/]+/[^/]+/environments$`), idField: "uuid"},
+	{pattern: regexp.MustCompile(`^2\.0/repositories/[^/]+/[^/]+/pipelines_config/variables$`), idField: "uuid"},
+	{pattern: regexp.MustCompile(`^2\.0/repositories/[^/]+/[^/]+/pipelines_config/schedules$`), idField: "uuid"},
+	{pattern: regexp.MustCompile(`^2\.0/repositories/[^/]+/[^/]+/pipelines_config/ssh/known_hosts$`), idField: "uuid"},
+	{pattern: regexp.MustCompile(`^2\.0/repositories/[^/]+/[^/]+/deployments_config/environments/[^/]+/variables$`), idField: "uuid"},
+	{pattern: regexp.MustCompile(`^2\.0/repositories/[^/]+/[^/]+/branch-restrictions$`), idField: "id", numeric: true},
+	{pattern: regexp.MustCompile(`^2\.0/repositories/[^/]+/[^/]+/deploy-keys$`), idField: "id", numeric: true},
+	{pattern: regexp.MustCompile(`^2\.0/repositories/[^/]+/[^/]+/refs/branches$`), idField: "name", idFrom: "name", escaped: true},
+	{pattern: regexp.MustCompile(`^2\.0/repositories/[^/]+/[^/]+/refs/tags$`), idField: "name", idFrom: "name", escaped: true},
+	// Bitbucket Data Center
+	{pattern: regexp.MustCompile(`^rest/api/1\.0/projects$`), idField: "key", idFrom: "key"},
+	{pattern: regexp.MustCompile(`^rest/api/1\.0/projects/[^/]+/repos$`), idField: "slug", idFrom: "name"},
+	{pattern: regexp.MustCompile(`^rest/api/1\.0/projects/[^/]+/repos/[^/]+/webhooks$`), idField: "id", numeric: true},
+	{pattern: regexp.MustCompile(`^rest/branch-permissions/2\.0/projects/[^/]+/repos/[^/]+/restrictions$`), idField: "id", numeric: true},
+	{pattern: regexp.MustCompile(`^rest/default-reviewers/1\.0/projects/[^/]+/repos/[^/]+/condition$`), idField: "id", numeric: true},
+}
+
+var (
+	// fakeLists are list endpoints whose objects are created with a PUT to their own path
+	fakeLists = []*regexp.Regexp{
+		regexp.MustCompile(`^1\.0/groups/[^/]+/[^/]+/members$`),
+		regexp.MustCompile(`^2\.0/repositories/[^/]+/[^/]+/default-reviewers$`),
+		regexp.MustCompile(`^2\.0/repositories/[^/]+/[^/]+/permissions-config/(users|groups)$`),
+		regexp.MustCompile(`^2\.0/workspaces/[^/]+/projects/[^/]+/permissions-config/(users|groups)$`),
+	}
+	fakeRepository     = regexp.MustCompile(`^2\.0/repositories/([^/]+)/([^/]+)$`)
+	fakeForks          = regexp.MustCompile(`^2\.0/repositories/([^/]+)/([^/]+)/forks$`)
+	fakeBranchingModel = regexp.MustCompile(`^2\.0/repositories/[^/]+/[^/]+/branching-model$`)
+	// fakeRefs are the paths that end in a branch or tag name or a revision, the name is kept as one escaped segment
+	fakeRefs = regexp.MustCompile(`^(2\.0/repositories/[^/]+/[^/]+/(?:refs/branches|refs/tags|commit))/(.+)$`)
+	// fakeTags creates annotated tags
+	fakeTags = regexp.MustCompile(`^2\.0/repositories/[^/]+/[^/]+/refs/tags$`)
+	// fakeCommit looks up commits by their hash or by the name of a branch pointing at them
+	fakeCommit = regexp.MustCompile(`^(2\.0/repositories/[^/]+/[^/]+)/commit/([^/]+)$`)
+	// fakePermissions are granted to the user or group at the end of their path
+	fakePermissions = regexp.MustCompile(`^2\.0/(?:repositories/[^/]+/[^/]+|workspaces/[^/]+/projects/[^/]+)/permissions-config/(users|groups)/([^/]+)$`)
+	// fakeDataCenterRepos creates Data Center repositories, their slug is derived from the name
+	fakeDataCenterRepos = regexp.MustCompile(`^rest/api/1\.0/projects/([^/]+)/repos$`)
+	// fakeReviewerConditions lists the default reviewer conditions Data Center creates at .../condition
+	fakeReviewerConditions = regexp.MustCompile(`^rest/default-reviewers/1\.0/projects/[^/]+/repos/[^/]+/conditions$`)
+)
+
+var (
+	// fakeReadOnlyFields are managed by the api, updates can't change them
+	fakeReadOnlyFields = []string{"uuid", "id", "full_name", "links", "type"}
+	// fakeKeptFields survive updates that don't send them
+	fakeKeptFields = []string{"slug", "project", "owner"}
+)
+
+// fakeValidations reject the values bitbucket rejects with an error of the field
+var fakeValidations = []struct {
+	collection *regexp.Regexp
+	field      string
+	valid      *regexp.Regexp
+	message    string
+}{
+	{regexp.MustCompile(`/variables$`), "key", regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`), "must be alphanumeric"},
+	{regexp.MustCompile(`/hooks$`), "url", regexp.MustCompile(`^https?://`), "Enter a valid URL."},
+}
+
+type fakeObject struct {
+	seq   int
+	value map[string]interface{}
+	// lag is the number of reads left that don't see the object yet, see fakeBitbucket.lag
+	lag int
+	// provisioning is the number of requests below the object left that are forbidden, see fakeBitbucket.provisioning
+	provisioning int
+}
+
+// fakeBitbucket is an in memory stand in for the parts of the bitbucket api the resources use. Objects are stored
+// by their path, creating one below a repository or group requires the repository or group to exist.
+type fakeBitbucket struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	seq     int
+	objects map[string]*fakeObject
+	// lag is the number of reads objects created through the api are not found by, like bitbucket's eventual
+	// consistency right after a create
+	lag int
+	// provisioning is the number of requests below objects created through the api that are forbidden, like the
+	// pipelines config of a fork bitbucket is still copying
+	provisioning int
+	// pageLen caps the number of values on a page of the 2.0 list endpoints, all values are on one page when it is 0
+	pageLen int
+}
+
+func newFakeBitbucket(t *testing.T) *fakeBitbucket {
+	f := &fakeBitbucket{objects: make(map[string]*fakeObject)}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// providerMeta configures the provider against the fake api and returns what it passes to the resources. overrides
+// replace or, when nil, remove arguments of the default configuration.
+func (f *fakeBitbucket) providerMeta(t *testing.T, overrides map[string]interface{}) interface{} {
+	config := map[string]interface{}{
+		"username":    "fake-user",
+		"password":    "fake-password",
+		"workspace":   "fake-workspace",
+		"api_url":     f.URL + "/",
+		"max_retries": 0,
+		"read_only":   false,
+	}
+	for k, v := range overrides {
+		if v == nil {
+			delete(config, k)
+		} else {
+			config[k] = v
+		}
+	}
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+	return p.Meta()
+}
+
+// seed stores value at path as if it had been created through the api
+func (f *fakeBitbucket) seed(path string, value map[string]interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.store(strings.Trim(path, "/"), value)
+}
+
+// seedRepository creates an empty git repository in workspace
+func (f *fakeBitbucket) seedRepository(workspace, slug string) {
+	f.seed(fmt.Sprintf("2.0/repositories/%s/%s", workspace, slug), f.newRepository(workspace, slug, nil))
+}
+
+// seedDataCenterRepository creates an empty git repository and its project on Bitbucket Data Center
+func (f *fakeBitbucket) seedDataCenterRepository(projectKey, slug string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	project := "rest/api/1.0/projects/" + projectKey
+	if _, ok := f.objects[project]; !ok {
+		f.seq++
+		f.store(project, map[string]interface{}{"id": f.seq, "key": projectKey, "name": projectKey, "public": false})
+	}
+
+	f.store(project+"/repos/"+slug, f.newDataCenterRepository(projectKey, map[string]interface{}{"name": slug, "slug": slug}))
+}
+
+// seedDataCenterUser creates a user on Bitbucket Data Center
+func (f *fakeBitbucket) seedDataCenterUser(slug string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	f.store("rest/api/1.0/users/"+slug, map[string]interface{}{"id": f.seq, "name": slug, "slug": slug, "displayName": slug})
+}
+
+// exists reports whether an object is stored at path
+func (f *fakeBitbucket) exists(path string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.objects[strings.Trim(path, "/")]
+	return ok
+}
+
+func (f *fakeBitbucket) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.Trim(r.URL.Path, "/")
+	if m := fakeRefs.FindStringSubmatch(strings.Trim(r.URL.EscapedPath(), "/")); m != nil {
+		path = m[1] + "/" + m[2]
+	}
+
+	if r.Header.Get("Authorization") == "" {
+		f.writeError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		f.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if parent := fakeParent(path); parent != "" {
+		obj, ok := f.objects[parent]
+		if !ok || obj.lag > 0 {
+			if ok {
+				obj.lag--
+			}
+			f.writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", parent))
+			return
+		}
+		if obj.provisioning > 0 {
+			obj.provisioning--
+			f.writeError(w, http.StatusForbidden, fmt.Sprintf("%s is still being provisioned", parent))
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if fakeReviewerConditions.MatchString(path) {
+			path = strings.TrimSuffix(path, "s")
+		}
+		f.get(w, path, r.URL.Query())
+	case http.MethodPost:
+		f.post(w, path, body)
+	case http.MethodPut:
+		f.put(w, path, body)
+	case http.MethodDelete:
+		f.delete(w, path)
+	default:
+		f.writeError(w, http.StatusMethodNotAllowed, r.Method+" is not supported")
+	}
+}
+
+func (f *fakeBitbucket) get(w http.ResponseWriter, path string, query url.Values) {
+	// the branching model is read from the repository but written to its settings
+	if fakeBranchingModel.MatchString(path) {
+		path += "/settings"
+	}
+
+	if obj, ok := f.objects[path]; ok && obj.lag > 0 {
+		obj.lag--
+		f.writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", path))
+		return
+	}
+
+	if obj, ok := f.objects[path]; ok {
+		f.writeJSON(w, http.StatusOK, fakeView(obj.value))
+		return
+	}
+
+	if m := fakeCommit.FindStringSubmatch(path); m != nil {
+		if branch, ok := f.objects[m[1]+"/refs/branches/"+m[2]]; ok {
+			hash, _ := branch.value["target"].(map[string]interface{})["hash"].(string)
+			if commit, ok := f.objects[m[1]+"/commit/"+hash]; ok {
+				f.writeJSON(w, http.StatusOK, fakeView(commit.value))
+				return
+			}
+		}
+	}
+
+	if _, ok := findFakeCollection(path); ok || matchesAny(fakeLists, path) {
+		values := f.children(path)
+		if strings.HasPrefix(path, "1.0/") || strings.HasPrefix(path, "rest/") {
+			f.writeJSON(w, http.StatusOK, values)
+			return
+		}
+		f.writeJSON(w, http.StatusOK, f.page(path, values, query))
+		return
+	}
+
+	f.writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", path))
+}
+
+func (f *fakeBitbucket) post(w http.ResponseWriter, path string, body []byte) {
+	if m := fakeRepository.FindStringSubmatch(path); m != nil {
+		if _, ok := f.objects[path]; ok {
+			f.writeError(w, http.StatusBadRequest, "Repository with this Slug and Owner already exists.")
+			return
+		}
+
+		value, err := decodeFakeBody(body)
+		if err != nil {
+			f.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		repo := f.newRepository(m[1], m[2], value)
+		f.create(path, repo)
+		f.writeJSON(w, http.StatusOK, fakeView(repo))
+		return
+	}
+
+	if m := fakeForks.FindStringSubmatch(path); m != nil {
+		f.fork(w, m[1]+"/"+m[2], body)
+		return
+	}
+
+	collection, ok := findFakeCollection(path)
+	if !ok {
+		f.writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", path))
+		return
+	}
+
+	var value map[string]interface{}
+	var id string
+
+	if f.invalid(w, path, body) {
+		return
+	}
+
+	if collection.form {
+		// the 1.0 groups endpoint takes a form and derives the slug from the name
+		form, err := url.ParseQuery(string(body))
+		if err != nil || form.Get("name") == "" {
+			f.writeError(w, http.StatusBadRequest, "name is required")
+			return
+		}
+
+		id = strings.ToLower(strings.ReplaceAll(form.Get("name"), " ", "-"))
+		value = map[string]interface{}{
+			"name":                      form.Get("name"),
+			"slug":                      id,
+			"auto_add":                  false,
+			"permission":                nil,
+			"email_forwarding_disabled": false,
+		}
+	} else {
+		var err error
+		value, err = decodeFakeBody(body)
+		if err != nil {
+			f.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		switch {
+		case collection.idFrom != "":
+			from, _ := value[collection.idFrom].(string)
+			if from == "" {
+				f.writeError(w, http.StatusBadRequest, collection.idFrom+" is required")
+				return
+			}
+			id = from
+			if collection.idField == "slug" {
+				id = strings.ToLower(strings.ReplaceAll(from, " ", "-"))
+			}
+			value[collection.idField] = id
+		case collection.numeric:
+			f.seq++
+			id = strconv.Itoa(f.seq)
+			value[collection.idField] = f.seq
+		default:
+			id = f.newUUID()
+			value[collection.idField] = id
+		}
+	}
+
+	if strings.HasPrefix(path, "rest/") {
+		f.dataCenterObject(path, value)
+	}
+
+	if collection.escaped {
+		id = url.PathEscape(id)
+	}
+
+	// tag messages are stored like git stores annotations, ending in a newline
+	if message, ok := value["message"].(string); ok && fakeTags.MatchString(path) && !strings.HasSuffix(message, "\n") {
+		value["message"] = message + "\n"
+	}
+
+	item := path + "/" + id
+	if _, ok := f.objects[item]; ok {
+		f.writeError(w, http.StatusBadRequest, fmt.Sprintf("%s already exists", item))
+		return
+	}
+
+	f.create(item, value)
+	f.writeJSON(w, http.StatusCreated, fakeView(value))
+}
+
+// page wraps the page of values query asks for in the envelope of the 2.0 list endpoints
+func (f *fakeBitbucket) page(path string, values []interface{}, query url.Values) map[string]interface{} {
+	page := map[string]interface{}{
+		"values":  values,
+		"page":    1,
+		"pagelen": len(values),
+		"size":    len(values),
+	}
+	if f.pageLen == 0 {
+		return page
+	}
+
+	n, _ := strconv.Atoi(query.Get("page"))
+	if n < 1 {
+		n = 1
+	}
+	start, end := (n-1)*f.pageLen, n*f.pageLen
+	if start > len(values) {
+		start = len(values)
+	}
+	if end >= len(values) {
+		end = len(values)
+	} else {
+		page["next"] = fmt.Sprintf("%s/%s?page=%d", f.URL, path, n+1)
+	}
+
+	page["values"] = values[start:end]
+	page["page"] = n
+	page["pagelen"] = f.pageLen
+	return page
+}
+
+// fork copies the repository parent to the workspace and name of the fork in body
+func (f *fakeBitbucket) fork(w http.ResponseWriter, parent string, body []byte) {
+	value, err := decodeFakeBody(body)
+	if err != nil {
+		f.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	workspace, _ := value["workspace"].(map[string]interface{})
+	name, _ := value["name"].(string)
+	if workspace == nil || workspace["slug"] == nil || name == "" {
+		f.writeError(w, http.StatusBadRequest, "workspace and name are required")
+		return
+	}
+	delete(value, "workspace")
+
+	slug := computeSlug(name)
+	path := fmt.Sprintf("2.0/repositories/%s/%s", workspace["slug"], slug)
+	if _, ok := f.objects[path]; ok {
+		f.writeError(w, http.StatusBadRequest, "Repository with this Slug and Owner already exists.")
+		return
+	}
+
+	repo := f.newRepository(workspace["slug"].(string), slug, value)
+	repo["parent"] = map[string]interface{}{"type": "repository", "full_name": parent}
+	f.create(path, repo)
+	f.writeJSON(w, http.StatusCreated, fakeView(repo))
+}
+
+func (f *fakeBitbucket) put(w http.ResponseWriter, path string, body []byte) {
+	// the branching model is reset by sending its settings without a body
+	if strings.HasSuffix(path, "/branching-model/settings") && len(body) == 0 {
+		delete(f.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	value, err := decodeFakeBody(body)
+	if err != nil {
+		f.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if f.invalid(w, path[:strings.LastIndex(path, "/")], body) {
+		return
+	}
+
+	// members and default reviewers are added by putting their id without a body
+	if len(body) == 0 {
+		value["uuid"] = path[strings.LastIndex(path, "/")+1:]
+	}
+
+	// permissions are listed with the user or group they are granted to
+	if m := fakePermissions.FindStringSubmatch(path); m != nil {
+		if m[1] == "users" {
+			value["user"] = map[string]interface{}{"uuid": m[2]}
+		} else {
+			value["group"] = map[string]interface{}{"slug": m[2]}
+		}
+	}
+
+	old, exists := f.objects[path]
+	if !exists {
+		if _, ok := findFakeCollection(path[:strings.LastIndex(path, "/")]); ok {
+			f.writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", path))
+			return
+		}
+	} else {
+		for _, field := range fakeReadOnlyFields {
+			if v, ok := old.value[field]; ok {
+				value[field] = v
+			}
+		}
+		for _, field := range fakeKeptFields {
+			if _, ok := value[field]; !ok {
+				if v, ok := old.value[field]; ok {
+					value[field] = v
+				}
+			}
+		}
+	}
+
+	f.store(path, value)
+	f.writeJSON(w, http.StatusOK, fakeView(value))
+}
+
+func (f *fakeBitbucket) delete(w http.ResponseWriter, path string) {
+	if _, ok := f.objects[path]; !ok {
+		f.writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", path))
+		return
+	}
+
+	for p := range f.objects {
+		if p == path || strings.HasPrefix(p, path+"/") {
+			delete(f.objects, p)
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// invalid answers with the field errors of body when an object posted to collection would be rejected
+func (f *fakeBitbucket) invalid(w http.ResponseWriter, collection string, body []byte) bool {
+	value, err := decodeFakeBody(body)
+	if err != nil {
+		return false
+	}
+
+	fields := make(map[string]interface{})
+	for _, v := range fakeValidations {
+		s, ok := value[v.field].(string)
+		if ok && v.collection.MatchString(collection) && !v.valid.MatchString(s) {
+			fields[v.field] = []string{v.message}
+		}
+	}
+
+	if len(fields) == 0 {
+		return false
+	}
+
+	f.writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+		"type": "error",
+		"error": map[string]interface{}{
+			"message": "Bad request",
+			"detail":  "The request is invalid.",
+			"fields":  fields,
+		},
+	})
+	return true
+}
+
+// store saves value at path, an object that is replaced keeps its position in lists
+func (f *fakeBitbucket) store(path string, value map[string]interface{}) {
+	if old, ok := f.objects[path]; ok {
+		old.value = value
+		return
+	}
+
+	f.seq++
+	f.objects[path] = &fakeObject{seq: f.seq, value: value}
+}
+
+// create stores an object created through the api, it is hidden from the next f.lag reads and the next
+// f.provisioning requests below it are forbidden
+func (f *fakeBitbucket) create(path string, value map[string]interface{}) {
+	f.store(path, value)
+	f.objects[path].lag = f.lag
+	f.objects[path].provisioning = f.provisioning
+}
+
+// children returns the objects stored directly below path in the order they were created
+func (f *fakeBitbucket) children(path string) []interface{} {
+	var objs []*fakeObject
+	for p, obj := range f.objects {
+		if rest := strings.TrimPrefix(p, path+"/"); rest != p && !strings.Contains(rest, "/") {
+			objs = append(objs, obj)
+		}
+	}
+
+	sort.Slice(objs, func(i, j int) bool { return objs[i].seq < objs[j].seq })
+
+	values := make([]interface{}, 0, len(objs))
+	for _, obj := range objs {
+		if obj.lag > 0 {
+			obj.lag--
+			continue
+		}
+		values = append(values, fakeView(obj.value))
+	}
+	return values
+}
+
+func (f *fakeBitbucket) newUUID() string {
+	f.seq++
+	return fmt.Sprintf("{%08x-0000-4000-8000-%012x}", f.seq, f.seq)
+}
+
+func (f *fakeBitbucket) newRepository(workspace, slug string, value map[string]interface{}) map[string]interface{} {
+	repo := map[string]interface{}{
+		"name":        slug,
+		"scm":         "git",
+		"is_private":  true,
+		"fork_policy": "allow_forks",
+		"project":     map[string]interface{}{"key": "PROJ"},
+	}
+	for k, v := range value {
+		repo[k] = v
+	}
+
+	repo["type"] = "repository"
+	repo["slug"] = slug
+	repo["uuid"] = f.newUUID()
+	repo["full_name"] = workspace + "/" + slug
+	repo["links"] = map[string]interface{}{
+		"avatar": map[string]interface{}{"href": fmt.Sprintf("%s/%s/%s/avatar", f.URL, workspace, slug)},
+		"clone": []interface{}{
+			map[string]interface{}{"name": "https", "href": fmt.Sprintf("%s/%s/%s.git", f.URL, workspace, slug)},
+			map[string]interface{}{"name": "ssh", "href": fmt.Sprintf("git@fake.bitbucket.org:%s/%s.git", workspace, slug)},
+		},
+	}
+
+	return repo
+}
+
+// dataCenterObject fills in what Data Center adds to the objects posted to path
+func (f *fakeBitbucket) dataCenterObject(path string, value map[string]interface{}) {
+	if _, ok := value["id"]; !ok {
+		f.seq++
+		value["id"] = f.seq
+	}
+
+	if m := fakeDataCenterRepos.FindStringSubmatch(path); m != nil {
+		for k, v := range f.newDataCenterRepository(m[1], value) {
+			value[k] = v
+		}
+	}
+
+	// branch permissions reference users by name but return the whole user
+	if users, ok := value["users"].([]interface{}); ok {
+		for i, name := range users {
+			users[i] = map[string]interface{}{"name": name, "slug": name}
+		}
+	}
+	delete(value, "accessKeys")
+}
+
+func (f *fakeBitbucket) newDataCenterRepository(projectKey string, value map[string]interface{}) map[string]interface{} {
+	repo := map[string]interface{}{
+		"scmId":    "git",
+		"forkable": true,
+		"public":   false,
+	}
+	for k, v := range value {
+		repo[k] = v
+	}
+
+	if _, ok := repo["id"]; !ok {
+		f.seq++
+		repo["id"] = f.seq
+	}
+	slug := repo["slug"].(string)
+	repo["project"] = map[string]interface{}{"key": projectKey}
+	repo["links"] = map[string]interface{}{
+		"clone": []interface{}{
+			map[string]interface{}{"name": "http", "href": fmt.Sprintf("%s/scm/%s/%s.git", f.URL, strings.ToLower(projectKey), slug)},
+			map[string]interface{}{"name": "ssh", "href": fmt.Sprintf("ssh://git@fake.bitbucket.local:7999/%s/%s.git", strings.ToLower(projectKey), slug)},
+		},
+	}
+
+	return repo
+}
+
+func (f *fakeBitbucket) writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func (f *fakeBitbucket) writeError(w http.ResponseWriter, status int, message string) {
+	f.writeJSON(w, status, map[string]interface{}{
+		"type":  "error",
+		"error": map[string]interface{}{"message": message},
+	})
+}
+
+// fakeParent returns the repository, group or Data Center project path objects below it belong to
+func fakeParent(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) > 4 && (parts[0] == "2.0" && parts[1] == "repositories" || parts[0] == "1.0" && parts[1] == "groups") {
+		return strings.Join(parts[:4], "/")
+	}
+	// rest/<api>/<version>/projects/<key>/repos/<slug>, all apis store the repository below rest/api/1.0
+	if len(parts) > 5 && parts[0] == "rest" && parts[3] == "projects" {
+		if len(parts) > 7 || len(parts) == 7 && parts[1] != "api" {
+			return "rest/api/1.0/projects/" + parts[4] + "/repos/" + parts[6]
+		}
+		return "rest/api/1.0/projects/" + parts[4]
+	}
+	return ""
+}
+
+// fakeView hides what the api never returns, secured variable values and private keys
+func fakeView(value map[string]interface{}) map[string]interface{} {
+	view := make(map[string]interface{}, len(value))
+	for k, v := range value {
+		view[k] = v
+	}
+
+	delete(view, "private_key")
+	if secured, _ := view["secured"].(bool); secured {
+		delete(view, "value")
+	}
+
+	return view
+}
+
+func findFakeCollection(path string) (fakeCollection, bool) {
+	for _, c := range fakeCollections {
+		if c.pattern.MatchString(path) {
+			return c, true
+		}
+	}
+	return fakeCollection{}, false
+}
+
+func matchesAny(patterns []*regexp.Regexp, path string) bool {
+	for _, p := range patterns {
+		if p.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeFakeBody(body []byte) (map[string]interface{}, error) {
+	value := make(map[string]interface{})
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return value, nil
+	}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, fmt.Errorf("malformed json: %w", err)
+	}
+	return value, nil
+}
+
+// offlineLifecycle describes a create, update, import and destroy run of a resource against the fake api
+type offlineLifecycle struct {
+	// resource is the type of the resource under test, e.g. bitbucket_hook
+	resource string
+	// steps are the configurations applied in order, each has to converge without a diff
+	steps []map[string]interface{}
+	// check is called with the state after every step
+	check func(t *testing.T, step int, state *terraform.InstanceState)
+	// importID returns the id to import the resource by, the import is skipped when it is nil
+	importID func(state *terraform.InstanceState) string
+	// importIgnore lists the attributes that can't be recovered on import, such as secrets
+	importIgnore []string
+	// provider overrides the provider configuration, see providerMeta
+	provider map[string]interface{}
+	// destroyed reports whether the resource is gone after it was destroyed. By default the resource has to be
+	// removed from state when it is read again.
+	destroyed func(state *terraform.InstanceState) bool
+}
+
+// testOfflineLifecycle runs l against a fresh provider configured for f. It drives the resource through the sdk
+// directly as the acceptance test framework needs a terraform binary.
+func testOfflineLifecycle(t *testing.T, f *fakeBitbucket, l offlineLifecycle) {
+	t.Helper()
+
+	ctx := context.Background()
+	meta := f.providerMeta(t, l.provider)
+	r := Provider().ResourcesMap[l.resource]
+	if r == nil {
+		t.Fatalf("unknown resource %s", l.resource)
+	}
+
+	var state *terraform.InstanceState
+
+	for i, raw := range l.steps {
+		config := terraform.NewResourceConfigRaw(raw)
+		if diags := r.Validate(config); diags.HasError() {
+			t.Fatalf("step %d: invalid config: %v", i, diags)
+		}
+
+		diff, err := r.Diff(ctx, state, config, meta)
+		if err != nil {
+			t.Fatalf("step %d: error planning: %s", i, err)
+		}
+		if state != nil && diff.RequiresNew() {
+			t.Fatalf("step %d: unexpected replacement: %#v", i, diff)
+		}
+
+		var diags diag.Diagnostics
+		// like terraform, a resource without changes isn't applied
+		if state == nil || diff != nil && !diff.Empty() {
+			state, diags = r.Apply(ctx, state, diff, meta)
+			if diags.HasError() {
+				t.Fatalf("step %d: error applying: %v", i, diags)
+			}
+		}
+		if state == nil || state.ID == "" {
+			t.Fatalf("step %d: resource is missing after apply", i)
+		}
+
+		state, diags = r.RefreshWithoutUpgrade(ctx, state, meta)
+		if diags.HasError() {
+			t.Fatalf("step %d: error refreshing: %v", i, diags)
+		}
+		if state == nil || state.ID == "" {
+			t.Fatalf("step %d: resource is missing after refresh", i)
+		}
+
+		diff, err = r.Diff(ctx, state, config, meta)
+		if err != nil {
+			t.Fatalf("step %d: error planning: %s", i, err)
+		}
+		if diff != nil && !diff.Empty() {
+			t.Fatalf("step %d: expected an empty plan after apply, got %#v", i, diff.Attributes)
+		}
+
+		if l.check != nil {
+			l.check(t, i, state)
+		}
+	}
+
+	if l.importID != nil {
+		imported, err := r.Importer.StateContext(ctx, r.Data(&terraform.InstanceState{ID: l.importID(state)}), meta)
+		if err != nil {
+			t.Fatalf("error importing: %s", err)
+		}
+		if len(imported) != 1 {
+			t.Fatalf("expected one imported resource, got %d", len(imported))
+		}
+
+		importedState, diags := r.RefreshWithoutUpgrade(ctx, imported[0].State(), meta)
+		if diags.HasError() {
+			t.Fatalf("error refreshing imported resource: %v", diags)
+		}
+		if importedState == nil {
+			t.Fatal("imported resource is missing")
+		}
+
+		ignored := map[string]bool{"%": true, "id": true}
+		for _, k := range l.importIgnore {
+			ignored[k] = true
+		}
+		for k, v := range state.Attributes {
+			if !ignored[k] && !strings.HasPrefix(k, "timeouts") && importedState.Attributes[k] != v {
+				t.Errorf("imported %s is %q, expected %q", k, importedState.Attributes[k], v)
+			}
+		}
+	}
+
+	if _, diags := r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, meta); diags.HasError() {
+		t.Fatalf("error destroying: %v", diags)
+	}
+
+	if l.destroyed != nil {
+		if !l.destroyed(state) {
+			t.Fatal("resource still exists after destroy")
+		}
+		return
+	}
+
+	refreshed, diags := r.RefreshWithoutUpgrade(ctx, state, meta)
+	if diags.HasError() {
+		t.Fatalf("error refreshing destroyed resource: %v", diags)
+	}
+	if refreshed != nil && refreshed.ID != "" {
+		t.Fatal("resource still exists after destroy")
+	}
+}
+
+// testOfflineApplyError creates resource from config against f and returns the diagnostics of the failed apply
+func testOfflineApplyError(t *testing.T, f *fakeBitbucket, resource string, config map[string]interface{}) diag.Diagnostics {
+	t.Helper()
+
+	ctx := context.Background()
+	meta := f.providerMeta(t, nil)
+	r := Provider().ResourcesMap[resource]
+
+	diff, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+
+	_, diags := r.Apply(ctx, nil, diff, meta)
+	if !diags.HasError() {
+		t.Fatal("expected the apply to fail")
+	}
+
+	return diags
+}
+
+func TestFakeBitbucket(t *testing.T) {
+	f := newFakeBitbucket(t)
+	username, password := "fake-user", "fake-password"
+	client := &Client{
+		Username:   &username,
+		Password:   &password,
+		HTTPClient: f.Client(),
+		BaseURL:    f.URL + "/",
+	}
+	ctx := context.Background()
+
+	if _, err := client.Get(ctx, "2.0/repositories/ws/repo/hooks"); !IsNotFound(err) {
+		t.Fatalf("expected a missing repository to be not found, got %v", err)
+	}
+
+	f.seedRepository("ws", "repo")
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Post(ctx, "2.0/repositories/ws/repo/hooks", bytes.NewBufferString(`{"url":"https://example.com"}`)); err != nil {
+			t.Fatalf("error creating hook: %s", err)
+		}
+	}
+
+	hooks, err := bitbucketapi.Paginate[bitbucketapi.Hook](ctx, client, "2.0/repositories/ws/repo/hooks", nil)
+	if err != nil {
+		t.Fatalf("error listing hooks: %s", err)
+	}
+	if len(hooks) != 2 || hooks[0].UUID == "" || hooks[0].UUID == hooks[1].UUID {
+		t.Fatalf("unexpected hooks %#v", hooks)
+	}
+
+	if _, err := client.Put(ctx, "2.0/repositories/ws/repo/hooks/{unknown}", bytes.NewBufferString(`{}`)); !IsNotFound(err) {
+		t.Errorf("expected updating an unknown hook to be not found, got %v", err)
+	}
+
+	if _, err := client.Delete(ctx, "2.0/repositories/ws/repo"); err != nil {
+		t.Fatalf("error deleting repository: %s", err)
+	}
+	if f.exists("2.0/repositories/ws/repo/hooks/" + hooks[0].UUID) {
+		t.Error("expected the hooks to be deleted with the repository")
+	}
+}
@@ -0,0 +1,158 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are never logged in clear text
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveFields are json or form fields that are never logged in clear text
+var sensitiveFields = map[string]bool{
+	"password":      true,
+	"private_key":   true,
+	"secret":        true,
+	"client_secret": true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+}
+
+// loggingTransport logs every request and response at DEBUG through tflog with credentials, secured variable
+// values and private keys redacted. It is shared by the internal Client and the generated api client.
+type loggingTransport struct {
+	transport http.RoundTripper
+}
+
+func newLoggingTransport(transport http.RoundTripper) *loggingTransport {
+	return &loggingTransport{transport: transport}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	fields := map[string]interface{}{
+		"tf_http_req_method":  req.Method,
+		"tf_http_req_uri":     req.URL.String(),
+		"tf_http_req_headers": redactHeaders(req.Header),
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			payload, _ := ioutil.ReadAll(body)
+			body.Close()
+			fields["tf_http_req_body"] = redactBody(payload, req.Header.Get("Content-Type"))
+		}
+	}
+
+	tflog.Debug(ctx, "Sending HTTP Request", fields)
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		tflog.Debug(ctx, "HTTP Request failed", map[string]interface{}{
+			"tf_http_req_method": req.Method,
+			"tf_http_req_uri":    req.URL.String(),
+			"error":              err.Error(),
+		})
+		return resp, err
+	}
+
+	fields = map[string]interface{}{
+		"tf_http_req_method":      req.Method,
+		"tf_http_req_uri":         req.URL.String(),
+		"tf_http_res_status_code": resp.StatusCode,
+		"tf_http_res_headers":     redactHeaders(resp.Header),
+	}
+
+	if resp.Body != nil {
+		payload, readErr := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(payload))
+		if readErr != nil {
+			return nil, readErr
+		}
+		fields["tf_http_res_body"] = redactBody(payload, resp.Header.Get("Content-Type"))
+	}
+
+	tflog.Debug(ctx, "Received HTTP Response", fields)
+
+	return resp, nil
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name := range header {
+		headers[name] = header.Get(name)
+	}
+
+	for _, name := range sensitiveHeaders {
+		if _, ok := headers[http.CanonicalHeaderKey(name)]; ok {
+			headers[http.CanonicalHeaderKey(name)] = redacted
+		}
+	}
+
+	return headers
+}
+
+// redactBody returns a loggable representation of a json or form encoded payload with sensitive fields redacted.
+// Payloads in any other format are not logged.
+func redactBody(payload []byte, contentType string) string {
+	if len(payload) == 0 {
+		return ""
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(payload, &body); err == nil {
+		redacted, err := json.Marshal(redactValue(body))
+		if err != nil {
+			return ""
+		}
+		return string(redacted)
+	}
+
+	if contentType == "" || strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if values, err := url.ParseQuery(string(payload)); err == nil {
+			for key := range values {
+				if sensitiveFields[strings.ToLower(key)] {
+					values.Set(key, redacted)
+				}
+			}
+			return values.Encode()
+		}
+	}
+
+	return "[non-json body omitted]"
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// the value of a secured pipeline or deployment variable
+		secured, _ := v["secured"].(bool)
+
+		for key, field := range v {
+			if sensitiveFields[strings.ToLower(key)] || (secured && key == "value") {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(field)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+		return v
+	}
+
+	return value
+}
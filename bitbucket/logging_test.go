@@ -0,0 +1,106 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	cases := []struct {
+		name        string
+		payload     string
+		contentType string
+		expected    string
+	}{
+		{
+			name:        "secured variable",
+			payload:     `{"key":"TOKEN","value":"hunter2","secured":true}`,
+			contentType: "application/json",
+			expected:    `{"key":"TOKEN","secured":true,"value":"[REDACTED]"}`,
+		},
+		{
+			name:        "plain variable",
+			payload:     `{"key":"REGION","value":"eu-west-1","secured":false}`,
+			contentType: "application/json",
+			expected:    `{"key":"REGION","secured":false,"value":"eu-west-1"}`,
+		},
+		{
+			name:        "nested private key",
+			payload:     `{"values":[{"private_key":"-----BEGIN","public_key":"ssh-rsa AAAA"}]}`,
+			contentType: "application/json",
+			expected:    `{"values":[{"private_key":"[REDACTED]","public_key":"ssh-rsa AAAA"}]}`,
+		},
+		{
+			name:        "token response",
+			payload:     `{"access_token":"abc","refresh_token":"def","token_type":"bearer"}`,
+			contentType: "application/json",
+			expected:    `{"access_token":"[REDACTED]","refresh_token":"[REDACTED]","token_type":"bearer"}`,
+		},
+		{
+			name:        "form",
+			payload:     "grant_type=client_credentials&client_secret=s3cret",
+			contentType: "application/x-www-form-urlencoded",
+			expected:    "client_secret=%5BREDACTED%5D&grant_type=client_credentials",
+		},
+		{
+			name:        "other",
+			payload:     "<html>oops</html>",
+			contentType: "text/html",
+			expected:    "[non-json body omitted]",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := redactBody([]byte(tc.payload), tc.contentType); got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer abc")
+	header.Set("Content-Type", "application/json")
+
+	headers := redactHeaders(header)
+
+	if headers["Authorization"] != redacted {
+		t.Errorf("expected authorization header to be redacted, got %s", headers["Authorization"])
+	}
+	if headers["Content-Type"] != "application/json" {
+		t.Errorf("expected content type to be kept, got %s", headers["Content-Type"])
+	}
+}
+
+func TestLoggingTransportPreservesBodies(t *testing.T) {
+	transport := newLoggingTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		payload, _ := ioutil.ReadAll(req.Body)
+		if string(payload) != `{"value":"x","secured":true}` {
+			t.Errorf("request body was modified: %s", payload)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"private_key":"k"}`)),
+		}, nil
+	}))
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://api.bitbucket.org/2.0/user",
+		bytes.NewBufferString(`{"value":"x","secured":true}`))
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, _ := ioutil.ReadAll(resp.Body)
+	if string(payload) != `{"private_key":"k"}` {
+		t.Errorf("response body was modified: %s", payload)
+	}
+}
@@ -0,0 +1,50 @@
+package bitbucket
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/DrFaust92/bitbucket-go-client"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	// BitbucketOAuthTokenURL is the endpoint used to exchange oauth consumer credentials for an access token
+	BitbucketOAuthTokenURL string = "https://bitbucket.org/site/oauth2/access_token"
+)
+
+// newClientCredentialsTokenSource runs the oauth client credentials grant against tokenURL. The returned token source
+// caches the access token and requests a new one shortly before it expires.
+func newClientCredentialsTokenSource(httpClient *http.Client, tokenURL, clientID, clientSecret string) oauth2.TokenSource {
+	conf := &clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     tokenURL,
+		AuthStyle:    oauth2.AuthStyleInHeader,
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+	return conf.TokenSource(ctx)
+}
+
+// tokenContext hands out the current access token of a token source as bitbucket.ContextAccessToken, so the
+// generated api client picks up refreshed tokens without the auth context having to be rebuilt.
+type tokenContext struct {
+	context.Context
+	tokenSource oauth2.TokenSource
+}
+
+func (c tokenContext) Value(key interface{}) interface{} {
+	if key == bitbucket.ContextAccessToken {
+		token, err := c.tokenSource.Token()
+		if err != nil {
+			log.Printf("[ERROR] Unable to obtain oauth access token: %s", err)
+			return nil
+		}
+		return token.AccessToken
+	}
+
+	return c.Context.Value(key)
+}
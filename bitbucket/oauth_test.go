@@ -0,0 +1,79 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestClientCredentialsTokenSource_refreshesExpiredTokens(t *testing.T) {
+	var issued int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "client-id" || pass != "client-secret" {
+			t.Errorf("unexpected client credentials %q/%q", user, pass)
+		}
+		if grant := r.FormValue("grant_type"); grant != "client_credentials" {
+			t.Errorf("unexpected grant type %q", grant)
+		}
+		// tokens expire within the refresh window so every lookup triggers a refresh
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":5}`, atomic.AddInt32(&issued, 1))
+	}))
+	defer tokenServer.Close()
+
+	var authHeaders []string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+	}))
+	defer apiServer.Close()
+
+	tokenSource := newClientCredentialsTokenSource(http.DefaultClient, tokenServer.URL, "client-id", "client-secret")
+	client := &Client{
+		HTTPClient:  http.DefaultClient,
+		BaseURL:     apiServer.URL + "/",
+		TokenSource: tokenSource,
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(context.Background(), "2.0/user"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if len(authHeaders) != 2 || authHeaders[0] != "Bearer token-1" || authHeaders[1] != "Bearer token-2" {
+		t.Fatalf("expected refreshed bearer tokens, got %v", authHeaders)
+	}
+
+	authCtx := tokenContext{Context: context.Background(), tokenSource: tokenSource}
+	if token := authCtx.Value(bitbucket.ContextAccessToken); token != "token-3" {
+		t.Fatalf("expected the auth context to hand out token-3, got %v", token)
+	}
+}
+
+func TestProviderConfigure_oauthTokenError(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"invalid_client","error_description":"Invalid OAuth client credentials"}`)
+	}))
+	defer tokenServer.Close()
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"oauth_client_id":     "client-id",
+		"oauth_client_secret": "client-secret",
+		"oauth_token_url":     tokenServer.URL,
+		"max_retries":         0,
+	}))
+
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "invalid_client") {
+		t.Fatalf("expected the token error to be reported, got %v", diags)
+	}
+}
@@ -0,0 +1,49 @@
+package bitbucket
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	// repositoryPermissions are the permission levels of repositories
+	repositoryPermissions = []string{"read", "write", "admin"}
+	// projectPermissions are the permission levels of projects, create-repo allows creating repositories in the
+	// project on top of write
+	projectPermissions = []string{"read", "write", "create-repo", "admin"}
+)
+
+// validateUserUUID accepts the braced uuids bitbucket identifies users by, e.g.
+// {4f3c2e1d-0000-4000-8000-000000000000}. Bitbucket returns them in lowercase, uppercase ones would never match
+// what is read back.
+var validateUserUUID schema.SchemaValidateFunc = validation.StringMatch(
+	regexp.MustCompile(`^\{[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\}$`),
+	"must be a lowercase user UUID including the braces, e.g. {4f3c2e1d-0000-4000-8000-000000000000}")
+
+// permissionId splits the id workspace/repo-slug/principal of a permission resource, principal is a user uuid or a
+// group slug
+func permissionId(id string) (string, string, string, error) {
+	parts := strings.Split(id, "/")
+
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("unexpected format of ID (%q), expected WORKSPACE-ID/REPO-SLUG/USER-UUID or WORKSPACE-ID/REPO-SLUG/GROUP-SLUG", id)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
+
+// projectPermissionId splits the id workspace/project-key/principal of a project permission resource, principal is
+// a user uuid or a group slug
+func projectPermissionId(id string) (string, string, string, error) {
+	parts := strings.Split(id, "/")
+
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("unexpected format of ID (%q), expected WORKSPACE-ID/PROJECT-KEY/USER-UUID or WORKSPACE-ID/PROJECT-KEY/GROUP-SLUG", id)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
@@ -5,9 +5,15 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
 type ProviderConfig struct {
@@ -15,57 +21,201 @@ type ProviderConfig struct {
 	AuthContext context.Context
 }
 
+// withAuth returns a context that is cancelled with ctx and carries the authentication of the generated api client
+func (c ProviderConfig) withAuth(ctx context.Context) context.Context {
+	return valueContext{Context: ctx, values: c.AuthContext}
+}
+
+// valueContext takes its deadline and cancellation from the embedded context and falls back to values for lookups
+// that the embedded context does not answer.
+type valueContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c valueContext) Value(key interface{}) interface{} {
+	if v := c.Context.Value(key); v != nil {
+		return v
+	}
+
+	return c.values.Value(key)
+}
+
 type Clients struct {
 	genClient  ProviderConfig
 	httpClient Client
+	api        *bitbucketapi.API
+	dataCenter DataCenterClient
+	workspace  string
+	flavor     string
 }
 
 // Provider will create the necessary terraform provider to talk to the Bitbucket APIs you should
 // specify a USERNAME and PASSWORD or a OAUTH Token
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"username": {
 				Optional:      true,
 				Type:          schema.TypeString,
 				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_USERNAME", nil),
-				ConflictsWith: []string{"oauth_token"},
+				ConflictsWith: []string{"oauth_token", "oauth_client_id", "personal_access_token"},
 				RequiredWith:  []string{"password"},
 			},
 			"password": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_PASSWORD", nil),
-				ConflictsWith: []string{"oauth_token"},
+				ConflictsWith: []string{"oauth_token", "oauth_client_id", "personal_access_token"},
 				RequiredWith:  []string{"username"},
 			},
 			"oauth_token": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_OAUTH_TOKEN", nil),
-				ConflictsWith: []string{"username", "password"},
+				ConflictsWith: []string{"username", "password", "oauth_client_id", "personal_access_token"},
+			},
+			"oauth_client_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_OAUTH_CLIENT_ID", nil),
+				ConflictsWith: []string{"username", "password", "oauth_token", "personal_access_token"},
+				RequiredWith:  []string{"oauth_client_secret"},
+			},
+			"oauth_client_secret": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_OAUTH_CLIENT_SECRET", nil),
+				ConflictsWith: []string{"username", "password", "oauth_token", "personal_access_token"},
+				RequiredWith:  []string{"oauth_client_id"},
+			},
+			"personal_access_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_PERSONAL_ACCESS_TOKEN", nil),
+				ConflictsWith: []string{"username", "password", "oauth_token", "oauth_client_id"},
+			},
+			"oauth_token_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BITBUCKET_OAUTH_TOKEN_URL", BitbucketOAuthTokenURL),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("BITBUCKET_WORKSPACE", nil),
+			},
+			"api_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BITBUCKET_API_URL", BitbucketEndpoint),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BITBUCKET_MAX_RETRIES", DefaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BITBUCKET_RETRY_MAX_WAIT", DefaultRetryMaxWait),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BITBUCKET_REQUESTS_PER_SECOND", 0),
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BITBUCKET_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("BITBUCKET_READ_ONLY", false),
+			},
+			"flavor": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BITBUCKET_FLAVOR", FlavorCloud),
+				ValidateFunc: validation.StringInSlice([]string{FlavorCloud, FlavorDataCenter}, false),
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BITBUCKET_PROXY_URL", nil),
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_CA_CERT_FILE", nil),
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("BITBUCKET_CA_CERT_PEM", nil),
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BITBUCKET_CLIENT_CERT", nil),
+				RequiredWith: []string{"client_key"},
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("BITBUCKET_CLIENT_KEY", nil),
+				RequiredWith: []string{"client_cert"},
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("BITBUCKET_INSECURE_SKIP_VERIFY", false),
 			},
 		},
-		ConfigureFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigure,
 		ResourcesMap: map[string]*schema.Resource{
-			"bitbucket_hook":                    resourceHook(),
-			"bitbucket_group":                   resourceGroup(),
-			"bitbucket_group_membership":        resourceGroupMembership(),
-			"bitbucket_default_reviewers":       resourceDefaultReviewers(),
-			"bitbucket_repository":              resourceRepository(),
-			"bitbucket_forked_repository":       resourceForkedRepository(),
-			"bitbucket_repository_variable":     resourceRepositoryVariable(),
-			"bitbucket_project":                 resourceProject(),
-			"bitbucket_deploy_key":              resourceDeployKey(),
-			"bitbucket_pipeline_ssh_key":        resourcePipelineSshKey(),
-			"bitbucket_pipeline_ssh_known_host": resourcePipelineSshKnownHost(),
-			"bitbucket_pipeline_schedule":       resourcePipelineSchedule(),
-			"bitbucket_ssh_key":                 resourceSshKey(),
-			"bitbucket_branch_restriction":      resourceBranchRestriction(),
-			"bitbucket_branching_model":         resourceBranchingModel(),
-			"bitbucket_deployment":              resourceDeployment(),
-			"bitbucket_deployment_variable":     resourceDeploymentVariable(),
-			"bitbucket_workspace_hook":          resourceWorkspaceHook(),
+			"bitbucket_hook":                        resourceHook(),
+			"bitbucket_group":                       resourceGroup(),
+			"bitbucket_group_membership":            resourceGroupMembership(),
+			"bitbucket_default_reviewers":           resourceDefaultReviewers(),
+			"bitbucket_repository":                  resourceRepository(),
+			"bitbucket_forked_repository":           resourceForkedRepository(),
+			"bitbucket_repository_variable":         resourceRepositoryVariable(),
+			"bitbucket_repository_variables":        resourceRepositoryVariables(),
+			"bitbucket_repository_user_permission":  resourceRepositoryUserPermission(),
+			"bitbucket_repository_group_permission": resourceRepositoryGroupPermission(),
+			"bitbucket_repository_access":           resourceRepositoryAccess(),
+			"bitbucket_project":                     resourceProject(),
+			"bitbucket_project_user_permission":     resourceProjectUserPermission(),
+			"bitbucket_project_group_permission":    resourceProjectGroupPermission(),
+			"bitbucket_deploy_key":                  resourceDeployKey(),
+			"bitbucket_pipeline_ssh_key":            resourcePipelineSshKey(),
+			"bitbucket_pipeline_ssh_known_host":     resourcePipelineSshKnownHost(),
+			"bitbucket_pipeline_schedule":           resourcePipelineSchedule(),
+			"bitbucket_tag":                         resourceTag(),
+			"bitbucket_ssh_key":                     resourceSshKey(),
+			"bitbucket_branch":                      resourceBranch(),
+			"bitbucket_branch_restriction":          resourceBranchRestriction(),
+			"bitbucket_branching_model":             resourceBranchingModel(),
+			"bitbucket_deployment":                  resourceDeployment(),
+			"bitbucket_deployment_variable":         resourceDeploymentVariable(),
+			"bitbucket_deployment_variables":        resourceDeploymentVariables(),
+			"bitbucket_workspace_hook":              resourceWorkspaceHook(),
+			"bitbucket_workspace_variable":          resourceWorkspaceVariable(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"bitbucket_group":                     dataGroup(),
@@ -79,22 +229,85 @@ func Provider() *schema.Provider {
 			"bitbucket_current_user":              dataCurrentUser(),
 			"bitbucket_workspace":                 dataWorkspace(),
 			"bitbucket_workspace_members":         dataWorkspaceMembers(),
+			"bitbucket_workspace_variables":       dataWorkspaceVariables(),
 		},
 	}
-}
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	for name, r := range p.ResourcesMap {
+		withResourceName(name, withFlavor(name, r))
+	}
+
+	for name, r := range p.DataSourcesMap {
+		withFlavor(name, r)
+	}
 
+	return p
+}
+
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	authCtx := context.Background()
 
+	apiURL := BitbucketEndpoint
+	if v, ok := d.GetOk("api_url"); ok && v.(string) != "" {
+		apiURL = strings.TrimSuffix(v.(string), "/") + "/"
+	}
+	log.Printf("[DEBUG] Using API URL %s", apiURL)
+
+	flavor := d.Get("flavor").(string)
+	if flavor == FlavorDataCenter && apiURL == BitbucketEndpoint {
+		return nil, diag.Errorf("api_url must point at the Bitbucket Data Center server when flavor is %q", FlavorDataCenter)
+	}
+
+	baseTransport, err := newBaseTransport(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	var transport http.RoundTripper = baseTransport
+
+	// acceptance tests record their traffic to a cassette and replay it offline
+	if path := os.Getenv(CassetteEnvVar); path != "" {
+		c, err := openCassette(path, os.Getenv(CassetteModeEnvVar))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		log.Printf("[DEBUG] Using %s cassette %s", c.mode, path)
+		transport = newCassetteTransport(c, transport)
+	}
+
+	transport = newLoggingTransport(transport)
+
+	transport = newRateLimitTransport(transport,
+		d.Get("requests_per_second").(float64),
+		d.Get("max_concurrent_requests").(int))
+
+	transport = newRetryTransport(transport,
+		d.Get("max_retries").(int),
+		time.Duration(d.Get("retry_max_wait").(int))*time.Second)
+
+	// the oauth token endpoint is always reachable, even when the provider is read only
+	tokenClient := &http.Client{
+		Transport: transport,
+	}
+
+	if d.Get("read_only").(bool) {
+		log.Printf("[DEBUG] Provider is read only, refusing to send requests that change anything")
+		transport = newReadOnlyTransport(transport)
+	}
+
+	httpClient := &http.Client{
+		Transport: transport,
+	}
+
 	client := &Client{
-		HTTPClient: &http.Client{},
+		HTTPClient: httpClient,
+		BaseURL:    apiURL,
 	}
 
 	if username, ok := d.GetOk("username"); ok {
 		var password interface{}
 		if password, ok = d.GetOk("password"); !ok {
-			return nil, fmt.Errorf("found username for basic auth, but password not specified")
+			return nil, diag.Errorf("found username for basic auth, but password not specified")
 		}
 		log.Printf("[DEBUG] Using API Basic Auth")
 
@@ -116,7 +329,35 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		authCtx = context.WithValue(authCtx, bitbucket.ContextAccessToken, token)
 	}
 
+	// personal and http access tokens are sent as bearer tokens just like oauth access tokens
+	if v, ok := d.GetOk("personal_access_token"); ok && v.(string) != "" {
+		log.Printf("[DEBUG] Using Personal Access Token")
+		token := v.(string)
+		client.OAuthToken = &token
+		authCtx = context.WithValue(authCtx, bitbucket.ContextAccessToken, token)
+	}
+
+	if clientID, ok := d.GetOk("oauth_client_id"); ok && clientID.(string) != "" {
+		var clientSecret interface{}
+		if clientSecret, ok = d.GetOk("oauth_client_secret"); !ok {
+			return nil, diag.Errorf("found oauth_client_id, but oauth_client_secret not specified")
+		}
+		log.Printf("[DEBUG] Using OAuth Client Credentials")
+
+		tokenSource := newClientCredentialsTokenSource(tokenClient, d.Get("oauth_token_url").(string),
+			clientID.(string), clientSecret.(string))
+
+		// the generated client can't report a failed token exchange, it would only see the 401 of the api
+		if _, err := tokenSource.Token(); err != nil {
+			return nil, diag.FromErr(fmt.Errorf("error obtaining oauth access token: %w", err))
+		}
+		client.TokenSource = tokenSource
+		authCtx = tokenContext{Context: authCtx, tokenSource: tokenSource}
+	}
+
 	conf := bitbucket.NewConfiguration()
+	conf.BasePath = apiURL + "2.0"
+	conf.HTTPClient = httpClient
 	apiClient := ProviderConfig{
 		ApiClient:   bitbucket.NewAPIClient(conf),
 		AuthContext: authCtx,
@@ -125,7 +366,28 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	clients := Clients{
 		genClient:  apiClient,
 		httpClient: *client,
+		api:        bitbucketapi.New(client),
+		dataCenter: DataCenterClient{client: client},
+		workspace:  d.Get("workspace").(string),
+		flavor:     flavor,
 	}
 
 	return clients, nil
 }
+
+// resolveWorkspace returns the workspace set on the resource under attribute and falls back to the provider workspace
+// when it is unset. The resolved value is recorded under attribute so it ends up in state.
+func resolveWorkspace(d *schema.ResourceData, m interface{}, attribute string) (string, error) {
+	if v, ok := d.GetOk(attribute); ok && v.(string) != "" {
+		return v.(string), nil
+	}
+
+	workspace := m.(Clients).workspace
+	if workspace == "" {
+		return "", fmt.Errorf("%s must be set, either on the resource or as workspace in the provider configuration", attribute)
+	}
+
+	d.Set(attribute, workspace)
+
+	return workspace, nil
+}
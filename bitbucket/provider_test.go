@@ -1,9 +1,14 @@
 package bitbucket
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -28,6 +33,10 @@ func TestProvider_impl(t *testing.T) {
 }
 
 func testAccPreCheck(t *testing.T) {
+	if os.Getenv(CassetteModeEnvVar) != "" && os.Getenv(CassetteEnvVar) == "" {
+		t.Fatal("acceptance tests have to call testAccCassette to be recorded or replayed")
+	}
+
 	if v := os.Getenv("BITBUCKET_USERNAME"); v == "" {
 		t.Fatal("BITBUCKET_USERNAME must be set for acceptence tests")
 	}
@@ -40,3 +49,193 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("BITBUCKET_TEAM must be set for acceptence tests")
 	}
 }
+
+const (
+	cassetteWorkspace = "tf-acc-workspace"
+	cassetteUsername  = "tf-acc-user"
+	// cassettePipelinedRepo stands in for BITBUCKET_PIPELINED_REPO, a repository with a pipeline defined
+	cassettePipelinedRepo = "tf-acc-pipelined-repo"
+)
+
+// testAccCassette records the http traffic of the acceptance test t to testdata/cassettes, or replays it from there,
+// when BITBUCKET_CASSETTE_MODE is set to record or replay. Replaying needs neither credentials nor network access, a
+// test without a recorded cassette fails. It returns the generator the test has to create its random values with, so
+// they are the same on replay as on record. Every acceptance test has to call it before it reads the environment.
+func testAccCassette(t *testing.T) *testAccRandom {
+	mode := os.Getenv(CassetteModeEnvVar)
+	if mode == "" {
+		return &testAccRandom{}
+	}
+
+	path := filepath.Join("testdata", "cassettes", strings.ReplaceAll(t.Name(), "/", "_")+".json")
+	if mode == cassetteModeReplay {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			t.Fatalf("no cassette recorded at %s, record it with make testrecord", path)
+		}
+	}
+
+	return testAccCassetteAt(t, path, mode)
+}
+
+func testAccCassetteAt(t *testing.T, path, mode string) *testAccRandom {
+	c, err := openCassette(path, mode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(CassetteEnvVar, path)
+	t.Setenv(CassetteModeEnvVar, mode)
+
+	t.Cleanup(func() {
+		if err := c.close(); err != nil {
+			t.Errorf("error writing cassette: %s", err)
+		}
+	})
+
+	if mode == cassetteModeReplay {
+		t.Setenv("BITBUCKET_USERNAME", cassetteUsername)
+		t.Setenv("BITBUCKET_PASSWORD", "replayed")
+		t.Setenv("BITBUCKET_TEAM", cassetteWorkspace)
+		t.Setenv("BITBUCKET_WORKSPACE", cassetteWorkspace)
+		t.Setenv("BITBUCKET_PIPELINED_REPO", cassettePipelinedRepo)
+		t.Setenv("BITBUCKET_OAUTH_TOKEN", "")
+		t.Setenv("BITBUCKET_OAUTH_CLIENT_ID", "")
+		t.Setenv("BITBUCKET_API_URL", "")
+	} else {
+		c.replace(os.Getenv("BITBUCKET_TEAM"), cassetteWorkspace)
+		c.replace(os.Getenv("BITBUCKET_WORKSPACE"), cassetteWorkspace)
+		c.replace(os.Getenv("BITBUCKET_USERNAME"), cassetteUsername)
+		c.replace(os.Getenv("BITBUCKET_PIPELINED_REPO"), cassettePipelinedRepo)
+	}
+
+	return &testAccRandom{cassette: c}
+}
+
+// testAccRandom generates the random values of an acceptance test. Values generated while a cassette is recorded
+// are stored in it as numbered placeholders, the replay generates the same placeholders instead of random values.
+// Without a cassette it is a plain random generator.
+type testAccRandom struct {
+	cassette *cassette
+	n        int
+}
+
+// generate returns a value created by random, or placeholder on replay
+func (r *testAccRandom) generate(placeholder func(n int) string, random func() string) string {
+	if r.cassette == nil {
+		return random()
+	}
+
+	r.n++
+	if r.cassette.mode == cassetteModeReplay {
+		return placeholder(r.n)
+	}
+
+	value := random()
+	r.cassette.replace(value, placeholder(r.n))
+	return value
+}
+
+// name returns a random name starting with prefix, see acctest.RandomWithPrefix
+func (r *testAccRandom) name(prefix string) string {
+	return r.generate(func(n int) string {
+		return fmt.Sprintf("%s-%019d", prefix, n)
+	}, func() string {
+		return acctest.RandomWithPrefix(prefix)
+	})
+}
+
+// stringFromCharSet returns a random string of length characters of charSet, see acctest.RandStringFromCharSet
+func (r *testAccRandom) stringFromCharSet(length int, charSet string) string {
+	return r.generate(func(n int) string {
+		// n written in the digits of charSet, padded with its first character
+		placeholder := make([]byte, length)
+		for i := length - 1; i >= 0; i-- {
+			placeholder[i] = charSet[n%len(charSet)]
+			n /= len(charSet)
+		}
+		return string(placeholder)
+	}, func() string {
+		return acctest.RandStringFromCharSet(length, charSet)
+	})
+}
+
+// sshKeyPair returns a random public key in OpenSSH format and its PEM encoded private key, see RandSSHKeyPairSize.
+// Only the key material of the public key is replaced on replay, so it keeps its type and comment.
+func (r *testAccRandom) sshKeyPair(keySize int, comment string) (string, string, error) {
+	publicKey, privateKey, err := RandSSHKeyPairSize(keySize, comment)
+	if err != nil || r.cassette == nil {
+		return publicKey, privateKey, err
+	}
+
+	fields := strings.Fields(publicKey)
+	fields[1] = r.generate(func(n int) string {
+		return fmt.Sprintf("AAAAtfacckey%019d", n)
+	}, func() string {
+		return fields[1]
+	})
+
+	return strings.Join(fields, " "), privateKey, nil
+}
+
+func TestResolveWorkspace(t *testing.T) {
+	m := Clients{workspace: "provider-workspace"}
+
+	d := schema.TestResourceDataRaw(t, resourceHook().Schema, map[string]interface{}{"owner": "resource-workspace"})
+	if workspace, err := resolveWorkspace(d, m, "owner"); err != nil || workspace != "resource-workspace" {
+		t.Fatalf("expected the resource workspace, got %q (%v)", workspace, err)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceHook().Schema, map[string]interface{}{})
+	if workspace, err := resolveWorkspace(d, m, "owner"); err != nil || workspace != "provider-workspace" {
+		t.Fatalf("expected the provider workspace, got %q (%v)", workspace, err)
+	}
+
+	if owner := d.Get("owner").(string); owner != "provider-workspace" {
+		t.Fatalf("expected the resolved workspace to be recorded, got %q", owner)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceHook().Schema, map[string]interface{}{})
+	if _, err := resolveWorkspace(d, Clients{}, "owner"); err == nil {
+		t.Fatal("expected an error when no workspace is configured")
+	}
+}
+
+func TestResourceDelete_offlineGone(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+	meta := f.providerMeta(t, nil)
+
+	const gone = "{00000000-0000-4000-8000-000000000001}"
+	for _, tc := range []struct {
+		resource string
+		id       string
+		config   map[string]interface{}
+	}{
+		{"bitbucket_branch_restriction", "1", map[string]interface{}{"owner": "fake-workspace", "repository": "offline-repo"}},
+		{"bitbucket_branching_model", "fake-workspace/gone-repo", nil},
+		{"bitbucket_default_reviewers", "fake-workspace/offline-repo/reviewers", map[string]interface{}{
+			"owner": "fake-workspace", "repository": "offline-repo", "reviewers": []interface{}{gone},
+		}},
+		{"bitbucket_deploy_key", "fake-workspace/offline-repo/1", nil},
+		{"bitbucket_deployment", gone, map[string]interface{}{"repository": "fake-workspace/offline-repo", "uuid": gone}},
+		{"bitbucket_deployment_variable", gone, map[string]interface{}{"deployment": "fake-workspace/offline-repo:" + gone, "uuid": gone}},
+		{"bitbucket_group", "fake-workspace/gone", nil},
+		{"bitbucket_group_membership", "fake-workspace/gone/" + gone, nil},
+		{"bitbucket_hook", gone, map[string]interface{}{"owner": "fake-workspace", "repository": "offline-repo"}},
+		{"bitbucket_pipeline_schedule", "fake-workspace/offline-repo/" + gone, nil},
+		{"bitbucket_pipeline_ssh_key", "fake-workspace/offline-repo", nil},
+		{"bitbucket_pipeline_ssh_known_host", "fake-workspace/offline-repo/" + gone, nil},
+		{"bitbucket_project", "fake-workspace/GONE", map[string]interface{}{"owner": "fake-workspace", "key": "GONE"}},
+		{"bitbucket_repository_variable", gone, map[string]interface{}{"repository": "fake-workspace/offline-repo", "uuid": gone}},
+		{"bitbucket_workspace_hook", gone, map[string]interface{}{"workspace": "fake-workspace"}},
+	} {
+		r := Provider().ResourcesMap[tc.resource]
+		d := schema.TestResourceDataRaw(t, r.Schema, tc.config)
+		d.SetId(tc.id)
+
+		// objects removed outside of terraform are already gone when they are destroyed
+		if diags := r.DeleteContext(context.Background(), d, meta); diags.HasError() {
+			t.Errorf("%s: expected deleting a missing object to succeed, got %v", tc.resource, diags)
+		}
+	}
+}
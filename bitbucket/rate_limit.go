@@ -0,0 +1,139 @@
+package bitbucket
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// rateLimitMinRate is the slowest pace the limiter throttles down to when bitbucket reports the quota is nearly used up
+	rateLimitMinRate rate.Limit = 0.1
+)
+
+// rateLimitTransport paces requests with a token bucket and caps the number of requests in flight. It is shared by
+// the internal Client and the generated api client so both draw from the same budget. The pace is lowered while
+// bitbucket's rate limit headers report the quota is nearly used up and restored once it recovers.
+type rateLimitTransport struct {
+	transport  http.RoundTripper
+	limiter    *rate.Limiter
+	baseLimit  rate.Limit
+	baseBurst  int
+	concurrent chan struct{}
+	mu         sync.Mutex
+}
+
+func newRateLimitTransport(transport http.RoundTripper, requestsPerSecond float64, maxConcurrent int) *rateLimitTransport {
+	limit := rate.Inf
+	burst := 1
+	if requestsPerSecond > 0 {
+		limit = rate.Limit(requestsPerSecond)
+		if requestsPerSecond > 1 {
+			burst = int(requestsPerSecond)
+		}
+	}
+
+	t := &rateLimitTransport{
+		transport: transport,
+		limiter:   rate.NewLimiter(limit, burst),
+		baseLimit: limit,
+		baseBurst: burst,
+	}
+
+	if maxConcurrent > 0 {
+		t.concurrent = make(chan struct{}, maxConcurrent)
+	}
+
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.concurrent != nil {
+		select {
+		case t.concurrent <- struct{}{}:
+			defer func() { <-t.concurrent }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := t.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	t.adjust(resp)
+
+	return resp, nil
+}
+
+// adjust adapts the pace of the limiter to the rate limit headers of the response.
+func (t *rateLimitTransport) adjust(resp *http.Response) {
+	limit, ok := quotaLimit(resp.Header)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if limit > t.baseLimit {
+		limit = t.baseLimit
+	}
+
+	if limit == t.limiter.Limit() {
+		return
+	}
+
+	log.Printf("[DEBUG] Adjusting request rate from %v to %v requests per second", t.limiter.Limit(), limit)
+	t.limiter.SetLimit(limit)
+	if limit == t.baseLimit {
+		t.limiter.SetBurst(t.baseBurst)
+	} else {
+		t.limiter.SetBurst(1)
+	}
+}
+
+// quotaLimit derives the pace that keeps within the remaining quota from bitbucket's rate limit headers.
+// It returns false when the response carries no rate limit information.
+func quotaLimit(header http.Header) (rate.Limit, bool) {
+	remaining, remainingErr := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	limit, limitErr := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	nearLimit := header.Get("X-RateLimit-NearLimit") == "true"
+
+	if remainingErr != nil && !nearLimit {
+		return 0, false
+	}
+
+	// bitbucket counts quotas per rolling hour, spread what is left over the remainder of the window
+	window := time.Hour
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		if untilReset := time.Until(time.Unix(reset, 0)); untilReset > 0 {
+			window = untilReset
+		}
+	}
+
+	if remainingErr == nil {
+		if limitErr == nil && limit > 0 && remaining*5 > limit && !nearLimit {
+			return rate.Inf, true
+		}
+
+		paced := rate.Limit(float64(remaining) / window.Seconds())
+		if paced < rateLimitMinRate {
+			paced = rateLimitMinRate
+		}
+		return paced, true
+	}
+
+	return rateLimitMinRate, true
+}
@@ -0,0 +1,89 @@
+package bitbucket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestRateLimitTransport_capsConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, 0, 2)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Fatalf("expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestRateLimitTransport_slowsDownNearQuota(t *testing.T) {
+	transport := newRateLimitTransport(http.DefaultTransport, 10, 0)
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "1000")
+	header.Set("X-RateLimit-Remaining", "36")
+	transport.adjust(&http.Response{Header: header})
+
+	if got := transport.limiter.Limit(); got != rateLimitMinRate {
+		t.Fatalf("expected the rate to drop to the minimum, got %v", got)
+	}
+
+	header.Set("X-RateLimit-Remaining", "900")
+	transport.adjust(&http.Response{Header: header})
+
+	if got := transport.limiter.Limit(); got != 10 {
+		t.Fatalf("expected the rate to be restored to 10, got %v", got)
+	}
+}
+
+func TestQuotaLimit(t *testing.T) {
+	if _, ok := quotaLimit(http.Header{}); ok {
+		t.Error("expected responses without rate limit headers to be ignored")
+	}
+
+	header := http.Header{}
+	header.Set("X-RateLimit-NearLimit", "true")
+	if limit, ok := quotaLimit(header); !ok || limit != rateLimitMinRate {
+		t.Errorf("expected the minimum rate when near the limit, got %v (%t)", limit, ok)
+	}
+
+	header = http.Header{}
+	header.Set("X-RateLimit-Limit", "1000")
+	header.Set("X-RateLimit-Remaining", "180")
+	header.Set("X-RateLimit-Reset", "0")
+	if limit, ok := quotaLimit(header); !ok || limit != rate.Limit(0.1) {
+		t.Errorf("expected 180 requests spread over an hour, got %v (%t)", limit, ok)
+	}
+}
@@ -0,0 +1,75 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ReadOnlyError is returned for requests that would change something while the provider is configured read only
+type ReadOnlyError struct {
+	Resource string
+	Method   string
+	URL      string
+}
+
+func (e *ReadOnlyError) Error() string {
+	if e.Resource == "" {
+		return fmt.Sprintf("refusing to send %s %s, the provider is configured with read_only = true", e.Method, e.URL)
+	}
+	return fmt.Sprintf("%s: refusing to send %s %s, the provider is configured with read_only = true", e.Resource, e.Method, e.URL)
+}
+
+// readOnlyTransport refuses every request that is not safe, i.e. anything but GET, HEAD and OPTIONS. It is shared by
+// the internal Client and the generated api client.
+type readOnlyTransport struct {
+	transport http.RoundTripper
+}
+
+func newReadOnlyTransport(transport http.RoundTripper) *readOnlyTransport {
+	return &readOnlyTransport{transport: transport}
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.transport.RoundTrip(req)
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	resource, _ := req.Context().Value(resourceNameKey{}).(string)
+
+	return nil, &ReadOnlyError{
+		Resource: resource,
+		Method:   req.Method,
+		URL:      req.URL.String(),
+	}
+}
+
+// resourceNameKey is the context key under which the type of the resource that sends a request is stored
+type resourceNameKey struct{}
+
+// withResourceName records name in the context of the create, update and delete functions of r so errors raised
+// further down, such as ReadOnlyError, can tell which resource made the request.
+func withResourceName(name string, r *schema.Resource) *schema.Resource {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return f(context.WithValue(ctx, resourceNameKey{}, name), d, m)
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+	r.DeleteContext = wrap(r.DeleteContext)
+
+	return r
+}
@@ -0,0 +1,97 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestReadOnlyTransport(t *testing.T) {
+	sent := 0
+	client := &Client{
+		HTTPClient: &http.Client{
+			Transport: newReadOnlyTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				sent++
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+				}, nil
+			})),
+		},
+	}
+
+	if _, err := client.Get(context.Background(), "2.0/repositories/ws/repo"); err != nil {
+		t.Fatalf("expected reads to pass, got %s", err)
+	}
+
+	ctx := context.WithValue(context.Background(), resourceNameKey{}, "bitbucket_hook")
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+		_, err := client.Do(ctx, method, "2.0/repositories/ws/repo/hooks", bytes.NewBufferString(`{}`), true)
+
+		var readOnlyErr *ReadOnlyError
+		if !errors.As(err, &readOnlyErr) {
+			t.Fatalf("expected %s to be refused, got %v", method, err)
+		}
+		if readOnlyErr.Resource != "bitbucket_hook" || readOnlyErr.Method != method ||
+			readOnlyErr.URL != "https://api.bitbucket.org/2.0/repositories/ws/repo/hooks" {
+			t.Errorf("unexpected error %s", readOnlyErr)
+		}
+	}
+
+	if sent != 1 {
+		t.Errorf("expected only the read to be sent, %d requests were sent", sent)
+	}
+}
+
+func TestWithResourceName(t *testing.T) {
+	var got string
+	r := withResourceName("bitbucket_hook", &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			got, _ = ctx.Value(resourceNameKey{}).(string)
+			return nil
+		},
+	})
+
+	r.CreateContext(context.Background(), nil, nil)
+
+	if got != "bitbucket_hook" {
+		t.Errorf("expected the resource name in the context, got %q", got)
+	}
+	if r.UpdateContext != nil {
+		t.Error("expected missing functions to stay unset")
+	}
+}
+
+func TestReadOnly_offlineForkedRepository(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("parent-workspace", "parent-repo")
+
+	ctx := context.Background()
+	meta := f.providerMeta(t, map[string]interface{}{"read_only": true})
+	r := Provider().ResourcesMap["bitbucket_forked_repository"]
+
+	diff, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":   "offline-fork",
+		"parent": map[string]interface{}{"owner": "parent-workspace", "slug": "parent-repo"},
+	}), meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+
+	// the generated client reports the refused request as a *url.Error instead of a GenericSwaggerError
+	_, diags := r.Apply(ctx, nil, diff, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "refusing to send POST") {
+		t.Errorf("expected the fork to be refused, got %#v", diags)
+	}
+	if f.exists("2.0/repositories/fake-workspace/offline-fork") {
+		t.Error("expected no fork to be created")
+	}
+}
@@ -0,0 +1,165 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
+)
+
+func resourceBranch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBranchCreate,
+		ReadContext:   resourceBranchRead,
+		UpdateContext: resourceBranchUpdate,
+		DeleteContext: resourceBranchDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"source": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				// the source only matters when the branch is created, replacing the branch would lose its commits
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != ""
+				},
+			},
+			"skip_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceBranchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+	repoSlug := d.Get("repository").(string)
+	name := d.Get("name").(string)
+	source := d.Get("source").(string)
+
+	// branches are created from a commit, a source branch is resolved to the commit it points at
+	commit, err := api.Commits.Get(ctx, workspace, repoSlug, source)
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error resolving source (%s) of Branch (%s): %w", source, name, err))
+	}
+
+	branch, err := api.Branches.Create(ctx, workspace, repoSlug, &bitbucketapi.Branch{Name: name, Target: &bitbucketapi.Commit{Hash: commit.Hash}})
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error creating Branch (%s): %w", name, err))
+	}
+
+	log.Printf("[DEBUG] Branch Create Response Decoded: %#v", branch)
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", workspace, repoSlug, name))
+
+	return resourceBranchRead(ctx, d, m)
+}
+
+func resourceBranchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, repoSlug, name, err := refId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	branch, err := api.Branches.Get(ctx, workspace, repoSlug, name)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Branch (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Branch (%s): %w", d.Id(), err))
+	}
+
+	d.Set("workspace", workspace)
+	d.Set("repository", repoSlug)
+	d.Set("name", branch.Name)
+	if branch.Target != nil {
+		d.Set("hash", branch.Target.Hash)
+	}
+
+	return nil
+}
+
+// resourceBranchUpdate only changes skip_destroy, the branch itself is left alone
+func resourceBranchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceBranchRead(ctx, d, m)
+}
+
+func resourceBranchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("skip_destroy").(bool) {
+		log.Printf("[DEBUG] Leaving Branch (%s) in place, skip_destroy is set", d.Id())
+		return nil
+	}
+
+	api := m.(Clients).api
+
+	workspace, repoSlug, name, err := refId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	err = api.Branches.Delete(ctx, workspace, repoSlug, name)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Branch (%s): %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+// refId splits the id workspace/repo-slug/name of a branch or tag, the name may contain slashes itself
+func refId(id string) (string, string, string, error) {
+	parts := strings.SplitN(id, "/", 3)
+
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("unexpected format of ID (%q), expected WORKSPACE-ID/REPO-SLUG/NAME", id)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
@@ -4,10 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
-
-	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/DrFaust92/bitbucket-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -44,8 +43,14 @@ func resourceBranchRestriction() *schema.Resource {
 		ReadContext:   resourceBranchRestrictionsRead,
 		UpdateContext: resourceBranchRestrictionsUpdate,
 		DeleteContext: resourceBranchRestrictionsDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idParts := strings.Split(d.Id(), "/")
 				if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected OWNER/REPO/BRANCH-RESTRICTION-ID", d.Id())
@@ -60,7 +65,8 @@ func resourceBranchRestriction() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"owner": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"repository": {
@@ -88,6 +94,11 @@ func resourceBranchRestriction() *schema.Resource {
 					"require_default_reviewer_approvals_to_merge",
 					"reset_pullrequest_approvals_on_change",
 					"delete",
+					// Bitbucket Data Center
+					"read-only",
+					"no-deletes",
+					"fast-forward-only",
+					"pull-request-only",
 				}, false),
 			},
 			"branch_match_kind": {
@@ -193,11 +204,15 @@ func resourceBranchRestrictionsCreate(ctx context.Context, d *schema.ResourceDat
 	branchRestriction := createBranchRestriction(d)
 
 	repo := d.Get("repository").(string)
-	workspace := d.Get("owner").(string)
-	branchRestrictionReq, _, err := brApi.RepositoriesWorkspaceRepoSlugBranchRestrictionsPost(c.AuthContext, *branchRestriction, repo, workspace)
+	workspace, err := resolveWorkspace(d, m, "owner")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	branchRestrictionReq, _, err := brApi.RepositoriesWorkspaceRepoSlugBranchRestrictionsPost(c.withAuth(ctx), *branchRestriction, repo, workspace)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return apiDiagnostics(d, err)
 	}
 
 	d.SetId(string(fmt.Sprintf("%v", branchRestrictionReq.Id)))
@@ -209,19 +224,19 @@ func resourceBranchRestrictionsRead(ctx context.Context, d *schema.ResourceData,
 	c := m.(Clients).genClient
 	brApi := c.ApiClient.BranchRestrictionsApi
 
-	brRes, res, err := brApi.RepositoriesWorkspaceRepoSlugBranchRestrictionsIdGet(c.AuthContext, url.PathEscape(d.Id()),
+	brRes, _, err := brApi.RepositoriesWorkspaceRepoSlugBranchRestrictionsIdGet(c.withAuth(ctx), url.PathEscape(d.Id()),
 		d.Get("repository").(string), d.Get("owner").(string))
 
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if res.StatusCode == http.StatusNotFound {
+	if IsNotFound(err) {
 		log.Printf("[WARN] Branch Restrictions (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
 	d.SetId(string(fmt.Sprintf("%v", brRes.Id)))
 	d.Set("kind", brRes.Kind)
 	d.Set("pattern", brRes.Pattern)
@@ -239,12 +254,12 @@ func resourceBranchRestrictionsUpdate(ctx context.Context, d *schema.ResourceDat
 	brApi := c.ApiClient.BranchRestrictionsApi
 	branchRestriction := createBranchRestriction(d)
 
-	_, _, err := brApi.RepositoriesWorkspaceRepoSlugBranchRestrictionsIdPut(c.AuthContext,
+	_, _, err := brApi.RepositoriesWorkspaceRepoSlugBranchRestrictionsIdPut(c.withAuth(ctx),
 		*branchRestriction, url.PathEscape(d.Id()),
 		d.Get("repository").(string), d.Get("owner").(string))
 
 	if err != nil {
-		return diag.FromErr(err)
+		return apiDiagnostics(d, err)
 	}
 
 	return resourceBranchRestrictionsRead(ctx, d, m)
@@ -254,11 +269,10 @@ func resourceBranchRestrictionsDelete(ctx context.Context, d *schema.ResourceDat
 	c := m.(Clients).genClient
 	brApi := c.ApiClient.BranchRestrictionsApi
 
-	_, err := brApi.RepositoriesWorkspaceRepoSlugBranchRestrictionsIdDelete(c.AuthContext, url.PathEscape(d.Id()),
+	_, err := brApi.RepositoriesWorkspaceRepoSlugBranchRestrictionsIdDelete(c.withAuth(ctx), url.PathEscape(d.Id()),
 		d.Get("repository").(string), d.Get("owner").(string))
-
-	if err != nil {
-		return diag.FromErr(err)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Branch Restriction (%s): %w", d.Id(), err))
 	}
 
 	return nil
@@ -0,0 +1,175 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Branch permissions of Bitbucket Data Center, owner is the key of the project the repository belongs to. Data
+// Center can't update a branch permission in place, updates replace it with a new one.
+
+var dataCenterRestrictionKinds = map[string]bool{
+	"read-only":         true,
+	"no-deletes":        true,
+	"fast-forward-only": true,
+	"pull-request-only": true,
+}
+
+func createDataCenterRestriction(d *schema.ResourceData) (*DataCenterRestriction, error) {
+	kind := d.Get("kind").(string)
+	if !dataCenterRestrictionKinds[kind] {
+		return nil, fmt.Errorf("kind %q is not supported on Bitbucket Data Center, use one of read-only, no-deletes, fast-forward-only or pull-request-only", kind)
+	}
+
+	restriction := &DataCenterRestriction{Type: kind}
+
+	switch d.Get("branch_match_kind").(string) {
+	case "branching_model":
+		branchType := d.Get("branch_type").(string)
+		if branchType == "" {
+			return nil, fmt.Errorf("branch_type must be set when branch_match_kind is branching_model")
+		}
+
+		if branchType == "development" || branchType == "production" {
+			restriction.Matcher = DataCenterMatcher{ID: branchType, Type: DataCenterMatcherType{ID: "MODEL_BRANCH"}}
+		} else {
+			restriction.Matcher = DataCenterMatcher{ID: strings.ToUpper(branchType), Type: DataCenterMatcherType{ID: "MODEL_CATEGORY"}}
+		}
+	default:
+		restriction.Matcher = DataCenterMatcher{ID: d.Get("pattern").(string), Type: DataCenterMatcherType{ID: "PATTERN"}}
+	}
+
+	for _, item := range d.Get("users").(*schema.Set).List() {
+		restriction.Users = append(restriction.Users, DataCenterUser{Name: item.(string)})
+	}
+
+	for _, item := range d.Get("groups").(*schema.Set).List() {
+		restriction.Groups = append(restriction.Groups, item.(map[string]interface{})["slug"].(string))
+	}
+
+	return restriction, nil
+}
+
+func resourceBranchRestrictionsDataCenterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	restriction, err := createDataCenterRestriction(d)
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	owner, err := resolveWorkspace(d, m, "owner")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	created, err := client.CreateRestriction(ctx, owner, d.Get("repository").(string), restriction)
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error creating Branch Restriction: %w", err))
+	}
+
+	d.SetId(strconv.Itoa(created.ID))
+
+	return resourceBranchRestrictionsDataCenterRead(ctx, d, m)
+}
+
+func resourceBranchRestrictionsDataCenterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	owner := d.Get("owner").(string)
+
+	restriction, err := client.GetRestriction(ctx, owner, d.Get("repository").(string), d.Id())
+	if IsNotFound(err) {
+		log.Printf("[WARN] Branch Restrictions (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Branch Restriction (%s): %w", d.Id(), err))
+	}
+
+	d.Set("kind", restriction.Type)
+
+	switch restriction.Matcher.Type.ID {
+	case "MODEL_BRANCH", "MODEL_CATEGORY":
+		d.Set("branch_match_kind", "branching_model")
+		d.Set("branch_type", strings.ToLower(restriction.Matcher.ID))
+		d.Set("pattern", "")
+	default:
+		d.Set("branch_match_kind", "glob")
+		d.Set("pattern", restriction.Matcher.ID)
+		d.Set("branch_type", "")
+	}
+
+	users := make([]string, 0, len(restriction.Users))
+	for _, user := range restriction.Users {
+		users = append(users, user.Name)
+	}
+	d.Set("users", users)
+
+	// Data Center groups have no owner, keep the configured one
+	groupOwners := make(map[string]string)
+	for _, item := range d.Get("groups").(*schema.Set).List() {
+		group := item.(map[string]interface{})
+		groupOwners[group["slug"].(string)] = group["owner"].(string)
+	}
+
+	groups := make([]map[string]interface{}, 0, len(restriction.Groups))
+	for _, slug := range restriction.Groups {
+		groupOwner, ok := groupOwners[slug]
+		if !ok {
+			groupOwner = owner
+		}
+		groups = append(groups, map[string]interface{}{
+			"owner": groupOwner,
+			"slug":  slug,
+		})
+	}
+	d.Set("groups", groups)
+
+	return nil
+}
+
+func resourceBranchRestrictionsDataCenterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	restriction, err := createDataCenterRestriction(d)
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	owner := d.Get("owner").(string)
+	repo := d.Get("repository").(string)
+
+	err = client.DeleteRestriction(ctx, owner, repo, d.Id())
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error updating Branch Restriction (%s): %w", d.Id(), err))
+	}
+
+	created, err := client.CreateRestriction(ctx, owner, repo, restriction)
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error updating Branch Restriction (%s): %w", d.Id(), err))
+	}
+
+	d.SetId(strconv.Itoa(created.ID))
+
+	return resourceBranchRestrictionsDataCenterRead(ctx, d, m)
+}
+
+func resourceBranchRestrictionsDataCenterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	err := client.DeleteRestriction(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Branch Restriction (%s): %w", d.Id(), err))
+	}
+
+	return nil
+}
@@ -7,13 +7,13 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccBitbucketBranchRestriction_basic(t *testing.T) {
-	rName := acctest.RandomWithPrefix("tf-test")
+	random := testAccCassette(t)
+	rName := random.name("tf-test")
 	testUser := os.Getenv("BITBUCKET_TEAM")
 	resourceName := "bitbucket_branch_restriction.test"
 
@@ -43,7 +43,8 @@ func TestAccBitbucketBranchRestriction_basic(t *testing.T) {
 }
 
 func TestAccBitbucketBranchRestriction_model(t *testing.T) {
-	rName := acctest.RandomWithPrefix("tf-test")
+	random := testAccCassette(t)
+	rName := random.name("tf-test")
 	testUser := os.Getenv("BITBUCKET_TEAM")
 	resourceName := "bitbucket_branch_restriction.test"
 
@@ -151,3 +152,42 @@ func testAccCheckBitbucketBranchRestrictionImportStateIdFunc(resourceName string
 		return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"], rs.Primary.ID), nil
 	}
 }
+
+func TestBitbucketBranchRestriction_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_branch_restriction",
+		steps: []map[string]interface{}{
+			{"repository": "offline-repo", "kind": "push", "pattern": "main"},
+			{"repository": "offline-repo", "kind": "push", "pattern": "release/*"},
+		},
+		importID: func(state *terraform.InstanceState) string {
+			return fmt.Sprintf("fake-workspace/offline-repo/%s", state.ID)
+		},
+	})
+}
+
+func TestBitbucketBranchRestriction_offlineDataCenter(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedDataCenterRepository("PROJ", "offline-repo")
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_branch_restriction",
+		provider: testDataCenterProvider,
+		steps: []map[string]interface{}{
+			{"owner": "PROJ", "repository": "offline-repo", "kind": "no-deletes", "pattern": "main"},
+			{"owner": "PROJ", "repository": "offline-repo", "kind": "read-only", "branch_match_kind": "branching_model", "branch_type": "release",
+				"users": []interface{}{"jdoe"}, "groups": []interface{}{map[string]interface{}{"owner": "PROJ", "slug": "developers"}}},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if step == 1 && !f.exists("rest/branch-permissions/2.0/projects/PROJ/repos/offline-repo/restrictions/"+state.ID) {
+				t.Errorf("expected the update to replace the restriction with %s", state.ID)
+			}
+		},
+		importID: func(state *terraform.InstanceState) string {
+			return fmt.Sprintf("PROJ/offline-repo/%s", state.ID)
+		},
+	})
+}
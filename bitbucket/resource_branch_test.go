@@ -0,0 +1,155 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccBitbucketBranch_basic(t *testing.T) {
+	random := testAccCassette(t)
+	resourceName := "bitbucket_branch.test"
+
+	workspace := os.Getenv("BITBUCKET_TEAM")
+	// a repository created by the test has no commits to branch from, so the bootstrapped repo is used
+	repo := os.Getenv("BITBUCKET_PIPELINED_REPO")
+	name := "release/" + random.name("tf-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketBranchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketBranchConfig(workspace, repo, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "workspace", workspace),
+					resource.TestCheckResourceAttr(resourceName, "repository", repo),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "source", "master"),
+					resource.TestCheckResourceAttrSet(resourceName, "hash"),
+					resource.TestCheckResourceAttrPair("bitbucket_branch_restriction.test", "pattern", resourceName, "name"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"source", "skip_destroy"},
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketBranchDestroy(s *terraform.State) error {
+	api := testAccProvider.Meta().(Clients).api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bitbucket_branch" {
+			continue
+		}
+
+		workspace, repoSlug, name, err := refId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = api.Branches.Get(context.Background(), workspace, repoSlug, name)
+		if err == nil {
+			return fmt.Errorf("Branch still exists")
+		}
+		if !IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccBitbucketBranchConfig(workspace, repo, name string) string {
+	return fmt.Sprintf(`
+resource "bitbucket_branch" "test" {
+  workspace  = %[1]q
+  repository = %[2]q
+  name       = %[3]q
+  source     = "master"
+}
+
+resource "bitbucket_branch_restriction" "test" {
+  owner      = bitbucket_branch.test.workspace
+  repository = bitbucket_branch.test.repository
+  kind       = "force"
+  pattern    = bitbucket_branch.test.name
+}
+`, workspace, repo, name)
+}
+
+const (
+	offlineCommit     = "0123456789abcdef0123456789abcdef01234567"
+	offlineRepository = "2.0/repositories/fake-workspace/offline-repo"
+)
+
+// seedBranches seeds the offline repository with a main branch pointing at offlineCommit
+func seedBranches(f *fakeBitbucket) {
+	f.seedRepository("fake-workspace", "offline-repo")
+	f.seed(offlineRepository+"/commit/"+offlineCommit, map[string]interface{}{"hash": offlineCommit})
+	f.seed(offlineRepository+"/refs/branches/main", map[string]interface{}{
+		"name": "main", "target": map[string]interface{}{"hash": offlineCommit},
+	})
+}
+
+func TestBitbucketBranch_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	seedBranches(f)
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_branch",
+		steps: []map[string]interface{}{
+			{"repository": "offline-repo", "name": "release/1.0", "source": "main"},
+			// the source only matters on create
+			{"repository": "offline-repo", "name": "release/1.0", "source": offlineCommit},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if state.ID != "fake-workspace/offline-repo/release/1.0" || state.Attributes["hash"] != offlineCommit {
+				t.Errorf("unexpected branch %s %#v", state.ID, state.Attributes)
+			}
+			if state.Attributes["source"] != "main" {
+				t.Errorf("expected the source to stay main, got %s", state.Attributes["source"])
+			}
+		},
+		importID:     func(state *terraform.InstanceState) string { return state.ID },
+		importIgnore: []string{"source", "skip_destroy"},
+	})
+}
+
+func TestBitbucketBranch_offlineSkipDestroy(t *testing.T) {
+	f := newFakeBitbucket(t)
+	seedBranches(f)
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_branch",
+		steps: []map[string]interface{}{
+			{"repository": "offline-repo", "name": "develop", "source": offlineCommit},
+			{"repository": "offline-repo", "name": "develop", "source": offlineCommit, "skip_destroy": true},
+		},
+		destroyed: func(state *terraform.InstanceState) bool {
+			return f.exists(offlineRepository + "/refs/branches/develop")
+		},
+	})
+}
+
+func TestBitbucketBranch_offlineUnknownSource(t *testing.T) {
+	f := newFakeBitbucket(t)
+	seedBranches(f)
+
+	diags := testOfflineApplyError(t, f, "bitbucket_branch", map[string]interface{}{
+		"repository": "offline-repo", "name": "develop", "source": "missing",
+	})
+
+	if len(diags) == 0 || f.exists(offlineRepository+"/refs/branches/develop") {
+		t.Errorf("expected the branch not to be created, got %#v", diags)
+	}
+}
@@ -1,53 +1,39 @@
 package bitbucket
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"net/http"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
-// BranchingModel is the data we need to send to create a new branching model for the repository
-type BranchingModel struct {
-	Development *BranchModel  `json:"development,omitempty"`
-	Production  *BranchModel  `json:"production,omitempty"`
-	BranchTypes []*BranchType `json:"branch_types"`
-}
-
-type BranchModel struct {
-	IsValid            bool   `json:"is_valid,omitempty"`
-	Name               string `json:"name,omitempty"`
-	UseMainbranch      bool   `json:"use_mainbranch,omitempty"`
-	BranchDoesNotExist bool   `json:"branch_does_not_exist,omitempty"`
-	Enabled            bool   `json:"enabled,omitempty"`
-}
-
-type BranchType struct {
-	Enabled bool   `json:"enabled,omitempty"`
-	Kind    string `json:"kind,omitempty"`
-	Prefix  string `json:"prefix,omitempty"`
-}
-
 func resourceBranchingModel() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBranchingModelsPut,
-		Read:   resourceBranchingModelsRead,
-		Update: resourceBranchingModelsPut,
-		Delete: resourceBranchingModelsDelete,
+		CreateContext: resourceBranchingModelsPut,
+		ReadContext:   resourceBranchingModelsRead,
+		UpdateContext: resourceBranchingModelsPut,
+		DeleteContext: resourceBranchingModelsDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
 			"owner": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"repository": {
@@ -137,71 +123,44 @@ func resourceBranchingModel() *schema.Resource {
 	}
 }
 
-func resourceBranchingModelsPut(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceBranchingModelsPut(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 	branchingModel := expandBranchingModel(d)
 
-	log.Printf("[DEBUG] Branching Model Request: %#v", branchingModel)
-	bytedata, err := json.Marshal(branchingModel)
-
+	owner, err := resolveWorkspace(d, m, "owner")
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	branchingModelReq, err := client.Put(fmt.Sprintf("2.0/repositories/%s/%s/branching-model/settings",
-		d.Get("owner").(string),
-		d.Get("repository").(string),
-	), bytes.NewBuffer(bytedata))
+	log.Printf("[DEBUG] Branching Model Request: %#v", branchingModel)
 
+	_, err = api.BranchingModels.Update(ctx, owner, d.Get("repository").(string), branchingModel)
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	body, readerr := ioutil.ReadAll(branchingModelReq.Body)
-	if readerr != nil {
-		return readerr
-	}
-
-	decodeerr := json.Unmarshal(body, &branchingModel)
-	if decodeerr != nil {
-		return decodeerr
-	}
-
-	d.SetId(string(fmt.Sprintf("%s/%s", d.Get("owner").(string), d.Get("repository").(string))))
+	d.SetId(string(fmt.Sprintf("%s/%s", owner, d.Get("repository").(string))))
 
-	return resourceBranchingModelsRead(d, m)
+	return resourceBranchingModelsRead(ctx, d, m)
 }
 
-func resourceBranchingModelsRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceBranchingModelsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
 	owner, repo, err := branchingModelId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
-	branchingModelsReq, _ := client.Get(fmt.Sprintf("2.0/repositories/%s/%s/branching-model", owner, repo))
+	branchingModel, err := api.BranchingModels.Get(ctx, owner, repo)
 
-	if branchingModelsReq.StatusCode == http.StatusNotFound {
+	if IsNotFound(err) {
 		log.Printf("[WARN] Branching Model (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
-	if branchingModelsReq.Body == nil {
-		return fmt.Errorf("error getting Branching Model (%s): empty response", d.Id())
-	}
-
-	var branchingModel *BranchingModel
-	body, readerr := ioutil.ReadAll(branchingModelsReq.Body)
-	if readerr != nil {
-		return readerr
-	}
-
-	log.Printf("[DEBUG] Branching Model Response JSON: %v", string(body))
-
-	decodeerr := json.Unmarshal(body, &branchingModel)
-	if decodeerr != nil {
-		return decodeerr
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Branching Model (%s): %w", d.Id(), err))
 	}
 
 	log.Printf("[DEBUG] Branching Model Response Decoded: %#v", branchingModel)
@@ -215,25 +174,24 @@ func resourceBranchingModelsRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceBranchingModelsDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceBranchingModelsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
 	owner, repo, err := branchingModelId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	_, err = client.Put(fmt.Sprintf("2.0/repositories/%s/%s/branching-model/settings", owner, repo), nil)
-
-	if err != nil {
-		return err
+	err = api.BranchingModels.Reset(ctx, owner, repo)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error resetting Branching Model (%s): %w", d.Id(), err))
 	}
 
-	return err
+	return nil
 }
 
-func expandBranchingModel(d *schema.ResourceData) *BranchingModel {
-	model := &BranchingModel{}
+func expandBranchingModel(d *schema.ResourceData) *bitbucketapi.BranchingModel {
+	model := &bitbucketapi.BranchingModel{}
 
 	if v, ok := d.GetOk("development"); ok && len(v.([]interface{})) > 0 && v.([]interface{}) != nil {
 		model.Development = expandBranchModel(v.([]interface{}))
@@ -246,13 +204,13 @@ func expandBranchingModel(d *schema.ResourceData) *BranchingModel {
 	if v, ok := d.GetOk("branch_type"); ok && v.(*schema.Set).Len() > 0 {
 		model.BranchTypes = expandBranchTypes(v.(*schema.Set))
 	} else {
-		model.BranchTypes = make([]*BranchType, 0)
+		model.BranchTypes = make([]*bitbucketapi.BranchType, 0)
 	}
 
 	return model
 }
 
-func expandBranchModel(l []interface{}) *BranchModel {
+func expandBranchModel(l []interface{}) *bitbucketapi.BranchModel {
 	if len(l) == 0 || l[0] == nil {
 		return nil
 	}
@@ -263,7 +221,7 @@ func expandBranchModel(l []interface{}) *BranchModel {
 		return nil
 	}
 
-	rp := &BranchModel{}
+	rp := &bitbucketapi.BranchModel{}
 
 	if v, ok := tfMap["name"].(string); ok {
 		rp.Name = v
@@ -284,7 +242,7 @@ func expandBranchModel(l []interface{}) *BranchModel {
 	return rp
 }
 
-func flattenBranchModel(rp *BranchModel, typ string) []interface{} {
+func flattenBranchModel(rp *bitbucketapi.BranchModel, typ string) []interface{} {
 	if rp == nil {
 		return []interface{}{}
 	}
@@ -304,12 +262,12 @@ func flattenBranchModel(rp *BranchModel, typ string) []interface{} {
 	return []interface{}{m}
 }
 
-func expandBranchTypes(tfList *schema.Set) []*BranchType {
+func expandBranchTypes(tfList *schema.Set) []*bitbucketapi.BranchType {
 	if tfList.Len() == 0 {
 		return nil
 	}
 
-	var branchTypes []*BranchType
+	var branchTypes []*bitbucketapi.BranchType
 
 	for _, tfMapRaw := range tfList.List() {
 		tfMap, ok := tfMapRaw.(map[string]interface{})
@@ -318,7 +276,7 @@ func expandBranchTypes(tfList *schema.Set) []*BranchType {
 			continue
 		}
 
-		bt := &BranchType{
+		bt := &bitbucketapi.BranchType{
 			Kind: tfMap["kind"].(string),
 		}
 
@@ -336,7 +294,7 @@ func expandBranchTypes(tfList *schema.Set) []*BranchType {
 	return branchTypes
 }
 
-func flattenBranchTypes(branchTypes []*BranchType) []interface{} {
+func flattenBranchTypes(branchTypes []*bitbucketapi.BranchType) []interface{} {
 	if len(branchTypes) == 0 {
 		return nil
 	}
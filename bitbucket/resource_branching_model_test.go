@@ -1,19 +1,21 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
 func TestAccBitbucketBranchingModel_basic(t *testing.T) {
-	var branchRestriction BranchingModel
-	rName := acctest.RandomWithPrefix("tf-test")
+	random := testAccCassette(t)
+	var branchRestriction bitbucketapi.BranchingModel
+	rName := random.name("tf-test")
 	testUser := os.Getenv("BITBUCKET_TEAM")
 	resourceName := "bitbucket_branching_model.test"
 
@@ -41,8 +43,9 @@ func TestAccBitbucketBranchingModel_basic(t *testing.T) {
 }
 
 func TestAccBitbucketBranchingModel_production(t *testing.T) {
-	var branchRestriction BranchingModel
-	rName := acctest.RandomWithPrefix("tf-test")
+	random := testAccCassette(t)
+	var branchRestriction bitbucketapi.BranchingModel
+	rName := random.name("tf-test")
 	testUser := os.Getenv("BITBUCKET_TEAM")
 	resourceName := "bitbucket_branching_model.test"
 
@@ -73,8 +76,9 @@ func TestAccBitbucketBranchingModel_production(t *testing.T) {
 }
 
 func TestAccBitbucketBranchingModel_branchTypes(t *testing.T) {
-	var branchRestriction BranchingModel
-	rName := acctest.RandomWithPrefix("tf-test")
+	random := testAccCassette(t)
+	var branchRestriction bitbucketapi.BranchingModel
+	rName := random.name("tf-test")
 	testUser := os.Getenv("BITBUCKET_TEAM")
 	resourceName := "bitbucket_branching_model.test"
 
@@ -191,7 +195,7 @@ func testAccCheckBitbucketBranchingModelDestroy(s *terraform.State) error {
 		if rs.Type != "bitbucket_branching_model" {
 			continue
 		}
-		response, err := client.Get(fmt.Sprintf("2.0/repositories/%s/%s/branching-model", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"]))
+		response, err := client.Get(context.Background(), fmt.Sprintf("2.0/repositories/%s/%s/branching-model", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"]))
 
 		if err == nil {
 			return fmt.Errorf("The resource was found should have errored")
@@ -205,7 +209,7 @@ func testAccCheckBitbucketBranchingModelDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckBitbucketBranchingModelExists(n string, branchRestriction *BranchingModel) resource.TestCheckFunc {
+func testAccCheckBitbucketBranchingModelExists(n string, branchRestriction *bitbucketapi.BranchingModel) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
@@ -217,3 +221,24 @@ func testAccCheckBitbucketBranchingModelExists(n string, branchRestriction *Bran
 		return nil
 	}
 }
+
+func TestBitbucketBranchingModel_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_branching_model",
+		steps: []map[string]interface{}{
+			{
+				"repository":  "offline-repo",
+				"development": []interface{}{map[string]interface{}{"use_mainbranch": true}},
+			},
+			{
+				"repository":  "offline-repo",
+				"development": []interface{}{map[string]interface{}{"name": "develop"}},
+				"production":  []interface{}{map[string]interface{}{"use_mainbranch": true, "enabled": true}},
+			},
+		},
+		importID: func(state *terraform.InstanceState) string { return state.ID },
+	})
+}
@@ -1,44 +1,37 @@
 package bitbucket
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-// Reviewer is teh default reviewer you want
-type Reviewer struct {
-	DisplayName string `json:"display_name,omitempty"`
-	UUID        string `json:"uuid,omitempty"`
-	Type        string `json:"type,omitempty"`
-}
-
-// PaginatedReviewers is a paginated list that the bitbucket api returns
-type PaginatedReviewers struct {
-	Values []Reviewer `json:"values,omitempty"`
-	Page   int        `json:"page,omitempty"`
-	Size   int        `json:"size,omitempty"`
-	Next   string     `json:"next,omitempty"`
-}
-
 func resourceDefaultReviewers() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDefaultReviewersCreate,
-		Read:   resourceDefaultReviewersRead,
-		Update: resourceDefaultReviewersUpdate,
-		Delete: resourceDefaultReviewersDelete,
+		CreateContext: resourceDefaultReviewersCreate,
+		ReadContext:   resourceDefaultReviewersRead,
+		UpdateContext: resourceDefaultReviewersUpdate,
+		DeleteContext: resourceDefaultReviewersDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
 			"owner": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"repository": {
@@ -55,75 +48,53 @@ func resourceDefaultReviewers() *schema.Resource {
 	}
 }
 
-func resourceDefaultReviewersCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDefaultReviewersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	prApi := c.ApiClient.PullrequestsApi
 
 	repo := d.Get("repository").(string)
-	workspace := d.Get("owner").(string)
+	workspace, err := resolveWorkspace(d, m, "owner")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
 	for _, user := range d.Get("reviewers").(*schema.Set).List() {
 		userName := user.(string)
-		_, reviewerResp, err := prApi.RepositoriesWorkspaceRepoSlugDefaultReviewersTargetUsernamePut(c.AuthContext, repo, userName, workspace)
+		_, reviewerResp, err := prApi.RepositoriesWorkspaceRepoSlugDefaultReviewersTargetUsernamePut(c.withAuth(ctx), repo, userName, workspace)
 
 		if err != nil {
-			return err
+			return apiDiagnostics(d, err)
 		}
 
 		if reviewerResp.StatusCode != 200 {
-			return fmt.Errorf("failed to create reviewer %s got code %d", userName, reviewerResp.StatusCode)
+			return diag.Errorf("failed to create reviewer %s got code %d", userName, reviewerResp.StatusCode)
 		}
 	}
 
 	d.SetId(fmt.Sprintf("%s/%s/reviewers", workspace, repo))
-	return resourceDefaultReviewersRead(d, m)
+	return resourceDefaultReviewersRead(ctx, d, m)
 }
 
-func resourceDefaultReviewersRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceDefaultReviewersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
 	owner, repo, err := defaultReviewersId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
-	resourceURL := fmt.Sprintf("2.0/repositories/%s/%s/default-reviewers", owner, repo)
-
-	res, err := client.Get(resourceURL)
-	if err != nil {
-		return err
-	}
-
-	if res.StatusCode == http.StatusNotFound {
+	reviewers, err := api.DefaultReviewers.List(ctx, owner, repo)
+	if IsNotFound(err) {
 		log.Printf("[WARN] Default Reviewers (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
-	var reviewers PaginatedReviewers
-	var terraformReviewers []string
-
-	for {
-		reviewersResponse, err := client.Get(resourceURL)
-		if err != nil {
-			return err
-		}
-
-		decoder := json.NewDecoder(reviewersResponse.Body)
-		err = decoder.Decode(&reviewers)
-		if err != nil {
-			return err
-		}
-
-		for _, reviewer := range reviewers.Values {
-			terraformReviewers = append(terraformReviewers, reviewer.UUID)
-		}
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
 
-		if reviewers.Next != "" {
-			nextPage := reviewers.Page + 1
-			resourceURL = fmt.Sprintf("2.0/repositories/%s/%s/default-reviewers?page=%d", owner, repo, nextPage)
-			reviewers = PaginatedReviewers{}
-		} else {
-			break
-		}
+	var terraformReviewers []string
+	for _, reviewer := range reviewers {
+		terraformReviewers = append(terraformReviewers, reviewer.UUID)
 	}
 
 	d.Set("owner", owner)
@@ -133,7 +104,7 @@ func resourceDefaultReviewersRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceDefaultReviewersUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDefaultReviewersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 
 	prApi := c.ApiClient.PullrequestsApi
@@ -148,37 +119,40 @@ func resourceDefaultReviewersUpdate(d *schema.ResourceData, m interface{}) error
 
 	for _, user := range add.List() {
 		userName := user.(string)
-		_, reviewerResp, err := prApi.RepositoriesWorkspaceRepoSlugDefaultReviewersTargetUsernamePut(c.AuthContext, repo, userName, workspace)
+		_, reviewerResp, err := prApi.RepositoriesWorkspaceRepoSlugDefaultReviewersTargetUsernamePut(c.withAuth(ctx), repo, userName, workspace)
 
 		if err != nil {
-			return err
+			return apiDiagnostics(d, err)
 		}
 
 		if reviewerResp.StatusCode != 200 {
-			return fmt.Errorf("failed to create reviewer %s got code %d", userName, reviewerResp.StatusCode)
+			return diag.Errorf("failed to create reviewer %s got code %d", userName, reviewerResp.StatusCode)
 		}
 	}
 
 	for _, user := range remove.List() {
 		userName := user.(string)
-		reviewerResp, err := prApi.RepositoriesWorkspaceRepoSlugDefaultReviewersTargetUsernameDelete(c.AuthContext, repo, userName, workspace)
+		reviewerResp, err := prApi.RepositoriesWorkspaceRepoSlugDefaultReviewersTargetUsernameDelete(c.withAuth(ctx), repo, userName, workspace)
+		if IsNotFound(err) {
+			continue
+		}
 
 		if err != nil {
-			return err
+			return apiDiagnostics(d, err)
 		}
 
 		if reviewerResp.StatusCode != 204 {
-			return fmt.Errorf("[%d] Could not delete %s from default reviewers",
+			return diag.Errorf("[%d] Could not delete %s from default reviewers",
 				reviewerResp.StatusCode,
 				userName,
 			)
 		}
 	}
 
-	return resourceDefaultReviewersRead(d, m)
+	return resourceDefaultReviewersRead(ctx, d, m)
 }
 
-func resourceDefaultReviewersDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDefaultReviewersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	prApi := c.ApiClient.PullrequestsApi
 
@@ -186,14 +160,17 @@ func resourceDefaultReviewersDelete(d *schema.ResourceData, m interface{}) error
 	workspace := d.Get("owner").(string)
 	for _, user := range d.Get("reviewers").(*schema.Set).List() {
 		userName := user.(string)
-		reviewerResp, err := prApi.RepositoriesWorkspaceRepoSlugDefaultReviewersTargetUsernameDelete(c.AuthContext, repo, userName, workspace)
+		reviewerResp, err := prApi.RepositoriesWorkspaceRepoSlugDefaultReviewersTargetUsernameDelete(c.withAuth(ctx), repo, userName, workspace)
+		if IsNotFound(err) {
+			continue
+		}
 
 		if err != nil {
-			return err
+			return apiDiagnostics(d, err)
 		}
 
 		if reviewerResp.StatusCode != 204 {
-			return fmt.Errorf("[%d] Could not delete %s from default reviewer",
+			return diag.Errorf("[%d] Could not delete %s from default reviewer",
 				reviewerResp.StatusCode,
 				userName,
 			)
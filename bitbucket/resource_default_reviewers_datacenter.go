@@ -0,0 +1,131 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Default reviewers of Bitbucket Data Center are kept in a single reviewer condition matching pull requests from any
+// branch to any branch. Reviewers are user slugs and the id is PROJECT-KEY/REPO-SLUG/CONDITION-ID.
+
+func createDataCenterReviewerCondition(ctx context.Context, client DataCenterClient, d *schema.ResourceData) (*DataCenterReviewerCondition, error) {
+	condition := &DataCenterReviewerCondition{
+		SourceMatcher: dataCenterAnyRef,
+		TargetMatcher: dataCenterAnyRef,
+		Reviewers:     make([]DataCenterUser, 0, d.Get("reviewers").(*schema.Set).Len()),
+	}
+
+	for _, item := range d.Get("reviewers").(*schema.Set).List() {
+		user, err := client.GetUser(ctx, item.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error reading reviewer (%s): %w", item.(string), err)
+		}
+		condition.Reviewers = append(condition.Reviewers, *user)
+	}
+
+	return condition, nil
+}
+
+func resourceDefaultReviewersDataCenterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	repo := d.Get("repository").(string)
+	owner, err := resolveWorkspace(d, m, "owner")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	condition, err := createDataCenterReviewerCondition(ctx, client, d)
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	created, err := client.CreateReviewerCondition(ctx, owner, repo, condition)
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error creating Default Reviewers: %w", err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%d", owner, repo, created.ID))
+	return resourceDefaultReviewersDataCenterRead(ctx, d, m)
+}
+
+func resourceDefaultReviewersDataCenterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	owner, repo, id, err := dataCenterRepoId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	conditions, err := client.ListReviewerConditions(ctx, owner, repo)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error reading Default Reviewers (%s): %w", d.Id(), err))
+	}
+
+	var condition *DataCenterReviewerCondition
+	for i := range conditions {
+		if strconv.Itoa(conditions[i].ID) == id {
+			condition = &conditions[i]
+			break
+		}
+	}
+
+	if condition == nil {
+		log.Printf("[WARN] Default Reviewers (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	reviewers := make([]string, 0, len(condition.Reviewers))
+	for _, reviewer := range condition.Reviewers {
+		reviewers = append(reviewers, reviewer.Slug)
+	}
+
+	d.Set("owner", owner)
+	d.Set("repository", repo)
+	d.Set("reviewers", reviewers)
+
+	return nil
+}
+
+func resourceDefaultReviewersDataCenterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	owner, repo, id, err := dataCenterRepoId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	condition, err := createDataCenterReviewerCondition(ctx, client, d)
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	_, err = client.UpdateReviewerCondition(ctx, owner, repo, id, condition)
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error updating Default Reviewers (%s): %w", d.Id(), err))
+	}
+
+	return resourceDefaultReviewersDataCenterRead(ctx, d, m)
+}
+
+func resourceDefaultReviewersDataCenterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	owner, repo, id, err := dataCenterRepoId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	err = client.DeleteReviewerCondition(ctx, owner, repo, id)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Default Reviewers (%s): %w", d.Id(), err))
+	}
+
+	return nil
+}
@@ -1,18 +1,19 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccBitbucketDefaultReviewers_basic(t *testing.T) {
-	rName := acctest.RandomWithPrefix("tf-test")
+	random := testAccCassette(t)
+	rName := random.name("tf-test")
 	owner := os.Getenv("BITBUCKET_TEAM")
 	resourceName := "bitbucket_default_reviewers.test"
 
@@ -62,7 +63,7 @@ func testAccCheckBitbucketDefaultReviewersDestroy(s *terraform.State) error {
 		if rs.Type != "bitbucket_default_reviewers" {
 			continue
 		}
-		response, _ := client.Get(fmt.Sprintf("2.0/repositories/%s/%s/default-reviewers", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"]))
+		response, _ := client.Get(context.Background(), fmt.Sprintf("2.0/repositories/%s/%s/default-reviewers", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"]))
 
 		if response.StatusCode != http.StatusNotFound {
 			return fmt.Errorf("Defaults Reviewer still exists")
@@ -86,3 +87,39 @@ func testAccCheckBitbucketDefaultReviewersExists(n string) resource.TestCheckFun
 		return nil
 	}
 }
+
+func TestBitbucketDefaultReviewers_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_default_reviewers",
+		steps: []map[string]interface{}{
+			{"repository": "offline-repo", "reviewers": []interface{}{"{one}", "{two}"}},
+			{"repository": "offline-repo", "reviewers": []interface{}{"{two}", "{three}"}},
+		},
+		importID: func(state *terraform.InstanceState) string { return state.ID },
+		destroyed: func(state *terraform.InstanceState) bool {
+			return !f.exists("2.0/repositories/fake-workspace/offline-repo/default-reviewers/{two}") &&
+				!f.exists("2.0/repositories/fake-workspace/offline-repo/default-reviewers/{three}")
+		},
+	})
+}
+
+func TestBitbucketDefaultReviewers_offlineDataCenter(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedDataCenterRepository("PROJ", "offline-repo")
+	for _, user := range []string{"one", "two", "three"} {
+		f.seedDataCenterUser(user)
+	}
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_default_reviewers",
+		provider: testDataCenterProvider,
+		steps: []map[string]interface{}{
+			{"owner": "PROJ", "repository": "offline-repo", "reviewers": []interface{}{"one", "two"}},
+			{"owner": "PROJ", "repository": "offline-repo", "reviewers": []interface{}{"two", "three"}},
+		},
+		importID: func(state *terraform.InstanceState) string { return state.ID },
+	})
+}
@@ -1,31 +1,37 @@
 package bitbucket
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"net/http"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceDeployKey() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDeployKeysCreate,
-		Read:   resourceDeployKeysRead,
-		Update: resourceDeployKeysUpdate,
-		Delete: resourceDeployKeysDelete,
+		CreateContext: resourceDeployKeysCreate,
+		ReadContext:   resourceDeployKeysRead,
+		UpdateContext: resourceDeployKeysUpdate,
+		DeleteContext: resourceDeployKeysDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
 			"workspace": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"repository": {
@@ -54,66 +60,50 @@ func resourceDeployKey() *schema.Resource {
 	}
 }
 
-func resourceDeployKeysCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceDeployKeysCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
 	deployKey := expandsshKey(d)
 	log.Printf("[DEBUG] Deploy Key Request: %#v", deployKey)
-	bytedata, err := json.Marshal(deployKey)
-
-	if err != nil {
-		return err
-	}
 
 	repo := d.Get("repository").(string)
-	workspace := d.Get("workspace").(string)
-	deployKeyReq, err := client.Post(fmt.Sprintf("2.0/repositories/%s/%s/deploy-keys", workspace, repo), bytes.NewBuffer(bytedata))
-
+	workspace, err := resolveWorkspace(d, m, "workspace")
 	if err != nil {
-		return err
-	}
-
-	body, readerr := ioutil.ReadAll(deployKeyReq.Body)
-	if readerr != nil {
-		return readerr
+		return apiDiagnostics(d, err)
 	}
+	deployKeyRes, err := api.DeployKeys.Create(ctx, workspace, repo, deployKey)
 
-	log.Printf("[DEBUG] Deploy Keys Create Response JSON: %v", string(body))
-
-	var deployKeyRes SshKey
-
-	decodeerr := json.Unmarshal(body, &deployKeyRes)
-	if decodeerr != nil {
-		return decodeerr
+	if err != nil {
+		return apiDiagnostics(d, err)
 	}
 
 	log.Printf("[DEBUG] Deploy Keys Create Response Decoded: %#v", deployKeyRes)
 
 	d.SetId(string(fmt.Sprintf("%s/%s/%d", workspace, repo, deployKeyRes.ID)))
 
-	return resourceDeployKeysRead(d, m)
+	return resourceDeployKeysRead(ctx, d, m)
 }
 
-func resourceDeployKeysRead(d *schema.ResourceData, m interface{}) error {
+func resourceDeployKeysRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	deployApi := c.ApiClient.DeploymentsApi
 
 	workspace, repo, keyId, err := deployKeyId(d.Id())
 	if err != nil {
-		return err
-	}
-
-	deployKey, deployKeyRes, err := deployApi.RepositoriesWorkspaceRepoSlugDeployKeysKeyIdGet(c.AuthContext, keyId, repo, workspace)
-	if err != nil {
-		return fmt.Errorf("error reading Deploy Key (%s): %w", d.Id(), err)
+		return apiDiagnostics(d, err)
 	}
 
-	if deployKeyRes.StatusCode == http.StatusNotFound {
+	deployKey, _, err := deployApi.RepositoriesWorkspaceRepoSlugDeployKeysKeyIdGet(c.withAuth(ctx), keyId, repo, workspace)
+	if IsNotFound(err) {
 		log.Printf("[WARN] Deploy Key (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Deploy Key (%s): %w", d.Id(), err))
+	}
+
 	log.Printf("[DEBUG] Deploy Key Response: %#v", deployKey)
 
 	d.Set("repository", repo)
@@ -126,47 +116,41 @@ func resourceDeployKeysRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceDeployKeysUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceDeployKeysUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
 	deployKey := expandsshKey(d)
 	log.Printf("[DEBUG] Deploy Key Request: %#v", deployKey)
-	bytedata, err := json.Marshal(deployKey)
-
-	if err != nil {
-		return err
-	}
 
 	workspace, repo, keyId, err := deployKeyId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	_, err = client.Put(fmt.Sprintf("2.0/repositories/%s/%s/deploy-keys/%s",
-		workspace, repo, keyId), bytes.NewBuffer(bytedata))
+	err = api.DeployKeys.Update(ctx, workspace, repo, keyId, deployKey)
 
 	if err != nil {
-		return fmt.Errorf("error updating Deploy Key (%s): %w", d.Id(), err)
+		return apiDiagnostics(d, fmt.Errorf("error updating Deploy Key (%s): %w", d.Id(), err))
 	}
 
-	return resourceDeployKeysRead(d, m)
+	return resourceDeployKeysRead(ctx, d, m)
 }
 
-func resourceDeployKeysDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDeployKeysDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	deployApi := c.ApiClient.DeploymentsApi
 
 	workspace, repo, keyId, err := deployKeyId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	_, err = deployApi.RepositoriesWorkspaceRepoSlugDeployKeysKeyIdDelete(c.AuthContext, keyId, repo, workspace)
-	if err != nil {
-		return fmt.Errorf("error deleting Deploy Key (%s): %w", d.Id(), err)
+	_, err = deployApi.RepositoriesWorkspaceRepoSlugDeployKeysKeyIdDelete(c.withAuth(ctx), keyId, repo, workspace)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Deploy Key (%s): %w", d.Id(), err))
 	}
 
-	return err
+	return nil
 }
 
 func deployKeyId(id string) (string, string, string, error) {
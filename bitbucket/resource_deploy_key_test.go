@@ -6,19 +6,20 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
 func TestAccBitbucketDeployKey_basic(t *testing.T) {
-	var deployKey SshKey
+	random := testAccCassette(t)
+	var deployKey bitbucketapi.SshKey
 	resourceName := "bitbucket_deploy_key.test"
 
-	rName := acctest.RandomWithPrefix("tf-test")
+	rName := random.name("tf-test")
 	owner := os.Getenv("BITBUCKET_TEAM")
 	userEmail := os.Getenv("BITBUCKET_USERNAME")
-	publicKey, _, err := RandSSHKeyPairSize(2048, userEmail)
+	publicKey, _, err := random.sshKeyPair(2048, userEmail)
 	if err != nil {
 		t.Fatalf("error generating random SSH key: %s", err)
 	}
@@ -49,13 +50,14 @@ func TestAccBitbucketDeployKey_basic(t *testing.T) {
 }
 
 func TestAccBitbucketDeployKey_label(t *testing.T) {
-	var deployKey SshKey
+	random := testAccCassette(t)
+	var deployKey bitbucketapi.SshKey
 	resourceName := "bitbucket_deploy_key.test"
 
 	owner := os.Getenv("BITBUCKET_TEAM")
-	rName := acctest.RandomWithPrefix("tf-test")
+	rName := random.name("tf-test")
 	userEmail := os.Getenv("BITBUCKET_USERNAME")
-	publicKey, _, err := RandSSHKeyPairSize(2048, userEmail)
+	publicKey, _, err := random.sshKeyPair(2048, userEmail)
 	if err != nil {
 		t.Fatalf("error generating random SSH key: %s", err)
 	}
@@ -113,7 +115,7 @@ func testAccCheckBitbucketDeployKeyDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckBitbucketDeployKeyExists(n string, deployKey *SshKey) resource.TestCheckFunc {
+func testAccCheckBitbucketDeployKeyExists(n string, deployKey *bitbucketapi.SshKey) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
@@ -156,3 +158,18 @@ resource "bitbucket_deploy_key" "test" {
 }
 `, workspace, rName, pubkey, label)
 }
+
+func TestBitbucketDeployKey_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_deploy_key",
+		steps: []map[string]interface{}{
+			{"repository": "offline-repo", "key": "ssh-ed25519 AAAA offline", "label": "offline"},
+			{"repository": "offline-repo", "key": "ssh-ed25519 AAAA offline", "label": "updated"},
+		},
+		importID:     func(state *terraform.InstanceState) string { return state.ID },
+		importIgnore: []string{"key"},
+	})
+}
@@ -1,35 +1,29 @@
 package bitbucket
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"net/http"
-	"net/url"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
-// Deployment structure for handling key info
-type Deployment struct {
-	Name  string `json:"name"`
-	Stage *Stage `json:"environment_type"`
-	UUID  string `json:"uuid,omitempty"`
-}
-
-type Stage struct {
-	Name string `json:"name"`
-}
-
 func resourceDeployment() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDeploymentCreate,
-		Update: resourceDeploymentUpdate,
-		Read:   resourceDeploymentRead,
-		Delete: resourceDeploymentDelete,
+		CreateContext: resourceDeploymentCreate,
+		UpdateContext: resourceDeploymentUpdate,
+		ReadContext:   resourceDeploymentRead,
+		DeleteContext: resourceDeploymentDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"uuid": {
@@ -58,114 +52,96 @@ func resourceDeployment() *schema.Resource {
 	}
 }
 
-func newDeploymentFromResource(d *schema.ResourceData) *Deployment {
-	dk := &Deployment{
+func newDeploymentFromResource(d *schema.ResourceData) *bitbucketapi.Deployment {
+	dk := &bitbucketapi.Deployment{
 		Name: d.Get("name").(string),
-		Stage: &Stage{
+		Stage: &bitbucketapi.Stage{
 			Name: d.Get("stage").(string),
 		},
 	}
 	return dk
 }
 
-func resourceDeploymentCreate(d *schema.ResourceData, m interface{}) error {
-
-	client := m.(Clients).httpClient
-	rvcr := newDeploymentFromResource(d)
-	bytedata, err := json.Marshal(rvcr)
+func resourceDeploymentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
+	api := m.(Clients).api
+	workspace, repoSlug, err := deployVarId(d.Get("repository").(string))
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
-	req, err := client.Post(fmt.Sprintf("2.0/repositories/%s/environments/",
-		d.Get("repository").(string),
-	), bytes.NewBuffer(bytedata))
 
+	deployment, err := api.Environments.Create(ctx, workspace, repoSlug, newDeploymentFromResource(d))
 	if err != nil {
-		return err
-	}
-
-	var deployment Deployment
-
-	body, readerr := ioutil.ReadAll(req.Body)
-	if readerr != nil {
-		return readerr
+		return apiDiagnostics(d, err)
 	}
 
-	decodeerr := json.Unmarshal(body, &deployment)
-	if decodeerr != nil {
-		return decodeerr
-	}
 	d.Set("uuid", deployment.UUID)
 	d.SetId(fmt.Sprintf("%s:%s", d.Get("repository"), deployment.UUID))
 
-	return resourceDeploymentRead(d, m)
-}
-
-func resourceDeploymentRead(d *schema.ResourceData, m interface{}) error {
-
-	client := m.(Clients).httpClient
-	req, _ := client.Get(fmt.Sprintf("2.0/repositories/%s/environments/%s",
-		d.Get("repository").(string),
-		d.Get("uuid").(string),
-	))
-
-	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	err = waitForObject(ctx, d.Timeout(schema.TimeoutCreate), fmt.Sprintf("Deployment (%s)", d.Id()), func() (bool, error) {
+		_, err := api.Environments.Get(ctx, workspace, repoSlug, deployment.UUID)
+		return err == nil, err
+	})
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
 
-	if req.StatusCode == 200 {
-		var Deployment Deployment
-		body, readerr := ioutil.ReadAll(req.Body)
-		if readerr != nil {
-			return readerr
-		}
+	return resourceDeploymentRead(ctx, d, m)
+}
 
-		decodeerr := json.Unmarshal(body, &Deployment)
-		if decodeerr != nil {
-			return decodeerr
-		}
+func resourceDeploymentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-		d.Set("uuid", Deployment.UUID)
-		d.Set("name", Deployment.Name)
-		d.Set("stage", Deployment.Stage.Name)
+	api := m.(Clients).api
+	workspace, repoSlug, err := deployVarId(d.Get("repository").(string))
+	if err != nil {
+		return apiDiagnostics(d, err)
 	}
 
-	if req.StatusCode == http.StatusNotFound {
+	deployment, err := api.Environments.Get(ctx, workspace, repoSlug, d.Get("uuid").(string))
+
+	if IsNotFound(err) {
+		log.Printf("[WARN] Deployment (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Deployment (%s): %w", d.Id(), err))
+	}
+
+	d.Set("uuid", deployment.UUID)
+	d.Set("name", deployment.Name)
+	d.Set("stage", deployment.Stage.Name)
+
 	return nil
 }
 
-func resourceDeploymentUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
-	rvcr := newDeploymentFromResource(d)
-	bytedata, err := json.Marshal(rvcr)
-
+func resourceDeploymentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+	workspace, repoSlug, err := deployVarId(d.Get("repository").(string))
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
-	req, err := client.Put(fmt.Sprintf("2.0/repositories/%s/environments/%s",
-		d.Get("repository").(string),
-		d.Get("uuid").(string),
-	), bytes.NewBuffer(bytedata))
 
+	err = api.Environments.Update(ctx, workspace, repoSlug, d.Get("uuid").(string), newDeploymentFromResource(d))
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	if req.StatusCode != 200 {
-		return nil
+	return resourceDeploymentRead(ctx, d, m)
+}
+
+func resourceDeploymentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+	workspace, repoSlug, err := deployVarId(d.Get("repository").(string))
+	if err != nil {
+		return apiDiagnostics(d, err)
 	}
 
-	return resourceDeploymentRead(d, m)
-}
+	err = api.Environments.Delete(ctx, workspace, repoSlug, d.Get("uuid").(string))
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Deployment (%s): %w", d.Id(), err))
+	}
 
-func resourceDeploymentDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
-	_, err := client.Delete(fmt.Sprintf("2.0/repositories/%s/environments/%s",
-		d.Get("repository").(string),
-		d.Get("uuid").(string),
-	))
-	return err
+	return nil
 }
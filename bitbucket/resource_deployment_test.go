@@ -1,6 +1,7 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
@@ -8,10 +9,12 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
 func TestAccBitbucketDeployment_basic(t *testing.T) {
-	var repo Deployment
+	testAccCassette(t)
+	var repo bitbucketapi.Deployment
 
 	testUser := os.Getenv("BITBUCKET_USERNAME")
 	testAccBitbucketDeploymentConfig := fmt.Sprintf(`
@@ -48,7 +51,7 @@ func testAccCheckBitbucketDeploymentDestroy(s *terraform.State) error {
 		return fmt.Errorf("Not found %s", "bitbucket_deployment.test_deploy")
 	}
 
-	response, _ := client.Get(fmt.Sprintf("2.0/repositories/%s/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["name"]))
+	response, _ := client.Get(context.Background(), fmt.Sprintf("2.0/repositories/%s/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["name"]))
 
 	if response.StatusCode != http.StatusNotFound {
 		return fmt.Errorf("Deployment still exists")
@@ -57,7 +60,7 @@ func testAccCheckBitbucketDeploymentDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckBitbucketDeploymentExists(n string, deployment *Deployment) resource.TestCheckFunc {
+func testAccCheckBitbucketDeploymentExists(n string, deployment *bitbucketapi.Deployment) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
@@ -69,3 +72,29 @@ func testAccCheckBitbucketDeploymentExists(n string, deployment *Deployment) res
 		return nil
 	}
 }
+
+func TestBitbucketDeployment_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_deployment",
+		steps: []map[string]interface{}{
+			{"repository": "fake-workspace/offline-repo", "name": "offline", "stage": "Test"},
+			{"repository": "fake-workspace/offline-repo", "name": "updated", "stage": "Test"},
+		},
+	})
+}
+
+func TestBitbucketDeployment_offlineEventuallyConsistent(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+	f.lag = 2
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_deployment",
+		steps: []map[string]interface{}{
+			{"repository": "fake-workspace/offline-repo", "name": "offline", "stage": "Test"},
+		},
+	})
+}
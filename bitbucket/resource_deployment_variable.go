@@ -1,22 +1,29 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"strings"
 	"time"
 
 	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceDeploymentVariable() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDeploymentVariableCreate,
-		Update: resourceDeploymentVariableUpdate,
-		Read:   resourceDeploymentVariableRead,
-		Delete: resourceDeploymentVariableDelete,
+		CreateContext: resourceDeploymentVariableCreate,
+		UpdateContext: resourceDeploymentVariableUpdate,
+		ReadContext:   resourceDeploymentVariableRead,
+		DeleteContext: resourceDeploymentVariableDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"uuid": {
@@ -59,7 +66,7 @@ func parseDeploymentId(str string) (repository string, deployment string) {
 	return parts[0], parts[1]
 }
 
-func resourceDeploymentVariableCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDeploymentVariableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 	rvcr := newDeploymentVariableFromResource(d)
@@ -67,52 +74,62 @@ func resourceDeploymentVariableCreate(d *schema.ResourceData, m interface{}) err
 	repository, deployment := parseDeploymentId(d.Get("deployment").(string))
 	workspace, repoSlug, err := deployVarId(repository)
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	rvRes, _, err := pipeApi.CreateDeploymentVariable(c.AuthContext, *rvcr, workspace, repoSlug, deployment)
+	rvRes, _, err := pipeApi.CreateDeploymentVariable(c.withAuth(ctx), *rvcr, workspace, repoSlug, deployment)
 
 	if err != nil {
-		return fmt.Errorf("error creating Deployment Variable (%s): %w", d.Get("deployment").(string), err)
+		return apiDiagnostics(d, fmt.Errorf("error creating Deployment Variable (%s): %w", d.Get("deployment").(string), err))
 	}
 
 	d.Set("uuid", rvRes.Uuid)
 	d.SetId(rvRes.Uuid)
 
-	time.Sleep(5000 * time.Millisecond) // sleep for a while, to allow BitBucket cache to catch up
-	return resourceDeploymentVariableRead(d, m)
+	// the variables of a deployment are cached, new ones show up in the list after a while
+	api := m.(Clients).api
+	err = waitForObject(ctx, d.Timeout(schema.TimeoutCreate), fmt.Sprintf("Deployment Variable (%s)", d.Id()), func() (bool, error) {
+		variables, err := api.DeploymentVariables.List(ctx, workspace, repoSlug, deployment)
+		if err != nil {
+			return false, err
+		}
+		for _, rv := range variables {
+			if rv.Uuid == rvRes.Uuid {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	return resourceDeploymentVariableRead(ctx, d, m)
 }
 
-func resourceDeploymentVariableRead(d *schema.ResourceData, m interface{}) error {
-	c := m.(Clients).genClient
-	pipeApi := c.ApiClient.PipelinesApi
+func resourceDeploymentVariableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
 	repository, deployment := parseDeploymentId(d.Get("deployment").(string))
 	workspace, repoSlug, err := deployVarId(repository)
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	rvRes, res, err := pipeApi.GetDeploymentVariables(c.AuthContext, workspace, repoSlug, deployment)
-	if err != nil {
-		return fmt.Errorf("error reading Deployment Variable (%s): %w", d.Id(), err)
-	}
-
-	if res.StatusCode == http.StatusNotFound {
+	variables, err := api.DeploymentVariables.List(ctx, workspace, repoSlug, deployment)
+	if IsNotFound(err) {
 		log.Printf("[WARN] Deployment Variable (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
-	if rvRes.Size < 1 {
-		log.Printf("[WARN] Deployment Variable (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Deployment Variable (%s): %w", d.Id(), err))
 	}
 
 	var deployVar *bitbucket.DeploymentVariable
 
-	for _, rv := range rvRes.Values {
+	for _, rv := range variables {
 		if rv.Uuid == d.Id() {
 			deployVar = &rv
 			break
@@ -138,7 +155,7 @@ func resourceDeploymentVariableRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceDeploymentVariableUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDeploymentVariableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 	rvcr := newDeploymentVariableFromResource(d)
@@ -146,31 +163,31 @@ func resourceDeploymentVariableUpdate(d *schema.ResourceData, m interface{}) err
 	repository, deployment := parseDeploymentId(d.Get("deployment").(string))
 	workspace, repoSlug, err := deployVarId(repository)
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	_, _, err = pipeApi.UpdateDeploymentVariable(c.AuthContext, *rvcr, workspace, repoSlug, deployment, d.Get("uuid").(string))
+	_, _, err = pipeApi.UpdateDeploymentVariable(c.withAuth(ctx), *rvcr, workspace, repoSlug, deployment, d.Get("uuid").(string))
 
 	if err != nil {
-		return fmt.Errorf("error updating Deployment Variable (%s): %w", d.Get("deployment").(string), err)
+		return apiDiagnostics(d, fmt.Errorf("error updating Deployment Variable (%s): %w", d.Get("deployment").(string), err))
 	}
 
-	return resourceDeploymentVariableRead(d, m)
+	return resourceDeploymentVariableRead(ctx, d, m)
 }
 
-func resourceDeploymentVariableDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDeploymentVariableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
 	repository, deployment := parseDeploymentId(d.Get("deployment").(string))
 	workspace, repoSlug, err := deployVarId(repository)
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	_, err = pipeApi.DeleteDeploymentVariable(c.AuthContext, workspace, repoSlug, deployment, d.Get("uuid").(string))
-	if err != nil {
-		return fmt.Errorf("error deleting Deployment Variable (%s): %w", d.Id(), err)
+	_, err = pipeApi.DeleteDeploymentVariable(c.withAuth(ctx), workspace, repoSlug, deployment, d.Get("uuid").(string))
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Deployment Variable (%s): %w", d.Id(), err))
 	}
 
 	return nil
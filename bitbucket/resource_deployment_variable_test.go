@@ -6,13 +6,13 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccBitbucketDeploymentVariable_basic(t *testing.T) {
-	rName := acctest.RandomWithPrefix("tf-test")
+	random := testAccCassette(t)
+	rName := random.name("tf-test")
 	owner := os.Getenv("BITBUCKET_TEAM")
 	resourceName := "bitbucket_deployment_variable.test"
 
@@ -46,7 +46,8 @@ func TestAccBitbucketDeploymentVariable_basic(t *testing.T) {
 }
 
 func TestAccBitbucketDeploymentVariable_secure(t *testing.T) {
-	rName := acctest.RandomWithPrefix("tf-test")
+	random := testAccCassette(t)
+	rName := random.name("tf-test")
 	owner := os.Getenv("BITBUCKET_TEAM")
 	resourceName := "bitbucket_deployment_variable.test"
 
@@ -149,3 +150,35 @@ resource "bitbucket_deployment_variable" "test" {
 }
 `, owner, rName, val, secure)
 }
+
+func TestBitbucketDeploymentVariable_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+	f.seed("2.0/repositories/fake-workspace/offline-repo/environments/{env}", map[string]interface{}{
+		"uuid": "{env}", "name": "offline", "environment_type": map[string]interface{}{"name": "Test"},
+	})
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_deployment_variable",
+		steps: []map[string]interface{}{
+			{"deployment": "fake-workspace/offline-repo:{env}", "key": "offline", "value": "plain"},
+			{"deployment": "fake-workspace/offline-repo:{env}", "key": "offline", "value": "hidden", "secured": true},
+		},
+	})
+}
+
+func TestBitbucketDeploymentVariable_offlineEventuallyConsistent(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+	f.seed("2.0/repositories/fake-workspace/offline-repo/environments/{env}", map[string]interface{}{
+		"uuid": "{env}", "name": "offline", "environment_type": map[string]interface{}{"name": "Test"},
+	})
+	f.lag = 2
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_deployment_variable",
+		steps: []map[string]interface{}{
+			{"deployment": "fake-workspace/offline-repo:{env}", "key": "offline", "value": "plain"},
+		},
+	})
+}
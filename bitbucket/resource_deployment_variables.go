@@ -0,0 +1,150 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// bitbucket_deployment_variables manages many variables of a deployment environment at once. The id is the id of the
+// deployment, workspace/repo-slug:environment-uuid.
+
+func resourceDeploymentVariables() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDeploymentVariablesCreate,
+		UpdateContext: resourceDeploymentVariablesUpdate,
+		ReadContext:   resourceDeploymentVariablesRead,
+		DeleteContext: resourceDeploymentVariablesDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDeploymentVariablesImport,
+		},
+
+		Schema: variablesSchema("deployment"),
+	}
+}
+
+// deploymentVariableClient manages the variables of the deployment with the id workspace/repo-slug:environment-uuid
+func deploymentVariableClient(m interface{}, id string) (variableClient, error) {
+	c := m.(Clients).genClient
+	pipeApi := c.ApiClient.PipelinesApi
+	api := m.(Clients).api
+
+	if !strings.Contains(id, ":") {
+		return variableClient{}, fmt.Errorf("incorrect ID format, should match `workspace/repo-slug:environment-uuid`")
+	}
+	repository, deployment := parseDeploymentId(id)
+	workspace, repoSlug, err := deployVarId(repository)
+	if err != nil {
+		return variableClient{}, err
+	}
+
+	return variableClient{
+		name: "Deployment Variable",
+		list: func(ctx context.Context) ([]bitbucket.PipelineVariable, error) {
+			deployVars, err := api.DeploymentVariables.List(ctx, workspace, repoSlug, deployment)
+			if err != nil {
+				return nil, err
+			}
+
+			variables := make([]bitbucket.PipelineVariable, 0, len(deployVars))
+			for _, v := range deployVars {
+				variables = append(variables, bitbucket.PipelineVariable{Uuid: v.Uuid, Key: v.Key, Value: v.Value, Secured: v.Secured})
+			}
+			return variables, nil
+		},
+		create: func(ctx context.Context, variable bitbucket.PipelineVariable) error {
+			_, _, err := pipeApi.CreateDeploymentVariable(c.withAuth(ctx), bitbucket.DeploymentVariable(variable), workspace, repoSlug, deployment)
+			return err
+		},
+		update: func(ctx context.Context, variable bitbucket.PipelineVariable) error {
+			_, _, err := pipeApi.UpdateDeploymentVariable(c.withAuth(ctx), bitbucket.DeploymentVariable(variable), workspace, repoSlug, deployment, variable.Uuid)
+			return err
+		},
+		delete: func(ctx context.Context, uuid string) error {
+			_, err := pipeApi.DeleteDeploymentVariable(c.withAuth(ctx), workspace, repoSlug, deployment, uuid)
+			return err
+		},
+	}, nil
+}
+
+func resourceDeploymentVariablesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	deployment := d.Get("deployment").(string)
+	if _, err := deploymentVariableClient(m, deployment); err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	d.SetId(deployment)
+
+	return resourceDeploymentVariablesUpdate(ctx, d, m)
+}
+
+func resourceDeploymentVariablesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := deploymentVariableClient(m, d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	err = readVariables(ctx, d, client)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Deployment Variables (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Deployment Variables (%s): %w", d.Id(), err))
+	}
+
+	d.Set("deployment", d.Id())
+
+	return nil
+}
+
+func resourceDeploymentVariablesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := deploymentVariableClient(m, d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	if err := reconcileVariables(ctx, d, client); err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	return resourceDeploymentVariablesRead(ctx, d, m)
+}
+
+func resourceDeploymentVariablesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := deploymentVariableClient(m, d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	return apiDiagnostics(d, deleteVariables(ctx, d, client))
+}
+
+func resourceDeploymentVariablesImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client, err := deploymentVariableClient(m, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := importVariables(ctx, d, client); err != nil {
+		return nil, err
+	}
+
+	d.Set("deployment", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,160 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccBitbucketDeploymentVariables_basic(t *testing.T) {
+	random := testAccCassette(t)
+	rName := random.name("tf-test")
+	owner := os.Getenv("BITBUCKET_TEAM")
+	resourceName := "bitbucket_deployment_variables.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketDeploymentVariablesConfig(owner, rName, "test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "deployment", "bitbucket_deployment.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "variable.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "variable.*", map[string]string{
+						"key":     "plain",
+						"value":   "test",
+						"secured": "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "variable.*", map[string]string{
+						"key":     "hidden",
+						"secured": "true",
+					}),
+				),
+			},
+			{
+				Config: testAccBitbucketDeploymentVariablesConfig(owner, rName, "test-2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "variable.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "variable.*", map[string]string{
+						"key":   "plain",
+						"value": "test-2",
+					}),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"exclusive", "variable"},
+			},
+		},
+	})
+}
+
+func testAccBitbucketDeploymentVariablesConfig(owner, rName, val string) string {
+	return fmt.Sprintf(`
+resource "bitbucket_repository" "test" {
+  owner = %[1]q
+  name  = %[2]q
+}
+
+resource "bitbucket_deployment" "test" {
+  name       = %[2]q
+  stage      = "Test"
+  repository = bitbucket_repository.test.id
+}
+
+resource "bitbucket_deployment_variables" "test" {
+  deployment = bitbucket_deployment.test.id
+  exclusive  = true
+
+  variable {
+    key   = "plain"
+    value = %[3]q
+  }
+
+  variable {
+    key     = "hidden"
+    value   = "secret"
+    secured = true
+  }
+}
+`, owner, rName, val)
+}
+
+const offlineDeploymentVariables = "2.0/repositories/fake-workspace/offline-repo/deployments_config/environments/{env}/variables"
+
+func TestBitbucketDeploymentVariables_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+	f.seed("2.0/repositories/fake-workspace/offline-repo/environments/{env}", map[string]interface{}{
+		"uuid": "{env}", "name": "offline", "environment_type": map[string]interface{}{"name": "Test"},
+	})
+	f.seed(offlineDeploymentVariables+"/{manual}", map[string]interface{}{
+		"uuid": "{manual}", "key": "MANUAL", "value": "by hand", "secured": false,
+	})
+	// the variables are spread over several pages
+	f.pageLen = 2
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_deployment_variables",
+		steps: []map[string]interface{}{
+			{
+				"deployment": "fake-workspace/offline-repo:{env}",
+				"variable": []interface{}{
+					map[string]interface{}{"key": "PLAIN", "value": "plain"},
+					map[string]interface{}{"key": "HIDDEN", "value": "hidden", "secured": true},
+				},
+			},
+			{
+				"deployment": "fake-workspace/offline-repo:{env}",
+				"variable": []interface{}{
+					map[string]interface{}{"key": "PLAIN", "value": "updated"},
+					map[string]interface{}{"key": "ADDED", "value": "added"},
+				},
+			},
+			{
+				"deployment": "fake-workspace/offline-repo:{env}",
+				"exclusive":  true,
+				"variable": []interface{}{
+					map[string]interface{}{"key": "PLAIN", "value": "updated"},
+					map[string]interface{}{"key": "ADDED", "value": "added"},
+				},
+			},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			expected := [][]string{
+				{"HIDDEN", "MANUAL", "PLAIN"},
+				{"ADDED", "MANUAL", "PLAIN"},
+				{"ADDED", "PLAIN"},
+			}[step]
+			if keys := fakeVariableKeys(f, offlineDeploymentVariables); fmt.Sprint(keys) != fmt.Sprint(expected) {
+				t.Errorf("step %d: expected the variables %v, got %v", step, expected, keys)
+			}
+			if state.ID != "fake-workspace/offline-repo:{env}" {
+				t.Errorf("expected the id of the deployment, got %s", state.ID)
+			}
+		},
+		importID:     func(state *terraform.InstanceState) string { return state.ID },
+		importIgnore: []string{"exclusive"},
+		// the environment outlives the resource, only the managed variables are deleted
+		destroyed: func(state *terraform.InstanceState) bool {
+			return len(fakeVariableKeys(f, offlineDeploymentVariables)) == 0
+		},
+	})
+}
+
+func TestBitbucketDeploymentVariables_invalidID(t *testing.T) {
+	f := newFakeBitbucket(t)
+
+	diags := testOfflineApplyError(t, f, "bitbucket_deployment_variables", map[string]interface{}{
+		"deployment": "fake-workspace/offline-repo",
+	})
+	if !diags.HasError() || diags[0].Summary != "incorrect ID format, should match `workspace/repo-slug:environment-uuid`" {
+		t.Errorf("expected the id to be rejected, got %v", diags)
+	}
+}
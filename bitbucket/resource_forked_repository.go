@@ -4,13 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"strings"
+	"time"
 
 	"github.com/DrFaust92/bitbucket-go-client"
 	"github.com/antihax/optional"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -18,9 +17,15 @@ import (
 func resourceForkedRepository() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceForkedRepositoryCreate,
-		Update:        resourceRepositoryUpdate,
+		UpdateContext: resourceRepositoryUpdate,
 		ReadContext:   resourceForkedRepositoryRead,
-		Delete:        resourceRepositoryDelete,
+		DeleteContext: resourceRepositoryDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -82,7 +87,8 @@ func resourceForkedRepository() *schema.Resource {
 			},
 			"owner": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"name": {
 				Type:     schema.TypeString,
@@ -192,7 +198,6 @@ func createForkedRepositoryFromRepository(repo *bitbucket.Repository, targetWork
 func resourceForkedRepositoryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	repoApi := c.ApiClient.RepositoriesApi
-	pipeApi := c.ApiClient.PipelinesApi
 	repo := newRepositoryFromResource(d)
 
 	var repoSlug string
@@ -202,7 +207,11 @@ func resourceForkedRepositoryCreate(ctx context.Context, d *schema.ResourceData,
 	}
 	repoSlug = computeSlug(repoSlug)
 
-	workspace := d.Get("owner").(string)
+	workspace, err := resolveWorkspace(d, m, "owner")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
 	parent := d.Get("parent").(map[string]interface{})
 	parentRepoSlug := parent["slug"].(string)
 	parentWorkspace := parent["owner"].(string)
@@ -210,34 +219,31 @@ func resourceForkedRepositoryCreate(ctx context.Context, d *schema.ResourceData,
 	repoBody := &bitbucket.RepositoriesApiRepositoriesWorkspaceRepoSlugForksPostOpts{
 		Body: optional.NewInterface(requestRepo),
 	}
-	_, _, err := repoApi.RepositoriesWorkspaceRepoSlugForksPost(c.AuthContext, parentRepoSlug, parentWorkspace, repoBody)
+	_, _, err = repoApi.RepositoriesWorkspaceRepoSlugForksPost(c.withAuth(ctx), parentRepoSlug, parentWorkspace, repoBody)
 	if err != nil {
-		swaggerErr := err.(bitbucket.GenericSwaggerError)
-		return diag.Errorf("error forking repository (%s) from (%s): %s", repoSlug, parentRepoSlug, string(swaggerErr.Body()))
+		return apiDiagnostics(d, fmt.Errorf("error forking repository (%s) from (%s): %w", repoSlug, parentRepoSlug, err))
 	}
 
-	d.SetId(string(fmt.Sprintf("%s/%s", d.Get("owner").(string), repoSlug)))
-
-	pipelinesEnabled := d.Get("pipelines_enabled").(bool)
-	pipelinesConfig := &bitbucket.PipelinesConfig{Enabled: pipelinesEnabled}
+	d.SetId(string(fmt.Sprintf("%s/%s", workspace, repoSlug)))
 
-	retryErr := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		_, pipelineResponse, err := pipeApi.UpdateRepositoryPipelineConfig(c.AuthContext, *pipelinesConfig, workspace, repoSlug)
-		if pipelineResponse.StatusCode == 403 || pipelineResponse.StatusCode == 404 {
-			return resource.RetryableError(
-				fmt.Errorf("Permissions error setting Pipelines config, retrying..."),
-			)
-		}
+	// forks are created asynchronously, they are ready once they are readable and linked to their parent
+	err = waitForObject(ctx, d.Timeout(schema.TimeoutCreate), fmt.Sprintf("forked repository (%s)", d.Id()), func() (bool, error) {
+		fork, _, err := repoApi.RepositoriesWorkspaceRepoSlugGet(c.withAuth(ctx), repoSlug, workspace)
 		if err != nil {
-			return resource.NonRetryableError(fmt.Errorf("unexpected error enabling pipeline for repository (%s): %w", repoSlug, err))
+			return false, err
 		}
-		return nil
+		return fork.Parent != nil, nil
 	})
-	if retryErr != nil {
-		return diag.FromErr(retryErr)
+	if err != nil {
+		return apiDiagnostics(d, err)
 	}
 
-	return diag.FromErr(resourceRepositoryRead(d, m))
+	// the pipelines can only be configured once the copy is done
+	if err := createRepositoryPipelineConfig(ctx, d, m, workspace, repoSlug); err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	return resourceRepositoryRead(ctx, d, m)
 }
 
 func resourceForkedRepositoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -264,17 +270,17 @@ func resourceForkedRepositoryRead(ctx context.Context, d *schema.ResourceData, m
 	repoApi := c.ApiClient.RepositoriesApi
 	pipeApi := c.ApiClient.PipelinesApi
 
-	repoRes, res, err := repoApi.RepositoriesWorkspaceRepoSlugGet(c.AuthContext, repoSlug, workspace)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error reading repository (%s): %w", d.Id(), err))
-	}
-
-	if res.StatusCode == http.StatusNotFound {
+	repoRes, _, err := repoApi.RepositoriesWorkspaceRepoSlugGet(c.withAuth(ctx), repoSlug, workspace)
+	if IsNotFound(err) {
 		log.Printf("[WARN] Repository (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading repository (%s): %w", d.Id(), err))
+	}
+
 	d.Set("scm", repoRes.Scm)
 	d.Set("is_private", repoRes.IsPrivate)
 	d.Set("has_wiki", repoRes.HasWiki)
@@ -309,16 +315,14 @@ func resourceForkedRepositoryRead(ctx context.Context, d *schema.ResourceData, m
 
 	d.Set("link", flattenLinks(repoRes.Links))
 
-	pipelinesConfigReq, res, err := pipeApi.GetRepositoryPipelineConfig(c.AuthContext, workspace, repoSlug)
-
-	if err != nil && res.StatusCode != http.StatusNotFound {
-		return diag.FromErr(err)
-	}
+	pipelinesConfigReq, _, err := pipeApi.GetRepositoryPipelineConfig(c.withAuth(ctx), workspace, repoSlug)
 
-	if res.StatusCode == 200 {
-		d.Set("pipelines_enabled", pipelinesConfigReq.Enabled)
-	} else if res.StatusCode == http.StatusNotFound {
+	if IsNotFound(err) {
 		d.Set("pipelines_enabled", false)
+	} else if err != nil {
+		return apiDiagnostics(d, err)
+	} else {
+		d.Set("pipelines_enabled", pipelinesConfigReq.Enabled)
 	}
 
 	return nil
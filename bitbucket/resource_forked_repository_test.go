@@ -5,12 +5,13 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccBitbucketForkedRepository_basic(t *testing.T) {
-	rName := acctest.RandomWithPrefix("tf-test")
+	random := testAccCassette(t)
+	rName := random.name("tf-test")
 	testUser := os.Getenv("BITBUCKET_TEAM")
 	resourceName := "bitbucket_forked_repository.test"
 
@@ -53,7 +54,8 @@ func TestAccBitbucketForkedRepository_basic(t *testing.T) {
 }
 
 func TestAccBitbucketForkedRepository_project(t *testing.T) {
-	rName := acctest.RandomWithPrefix("tf-test")
+	random := testAccCassette(t)
+	rName := random.name("tf-test")
 	testUser := os.Getenv("BITBUCKET_TEAM")
 	resourceName := "bitbucket_forked_repository.test"
 
@@ -127,3 +129,27 @@ resource "bitbucket_forked_repository" "test" {
 }
 `, testUser, rName)
 }
+
+func TestBitbucketForkedRepository_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("parent-workspace", "parent-repo")
+	// the fork isn't readable right away and its pipelines can't be configured until it is copied
+	f.lag = 2
+	f.provisioning = 2
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_forked_repository",
+		steps: []map[string]interface{}{
+			{
+				"name":              "offline-fork",
+				"parent":            map[string]interface{}{"owner": "parent-workspace", "slug": "parent-repo"},
+				"pipelines_enabled": true,
+			},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if state.ID != "fake-workspace/offline-fork" || state.Attributes["parent.owner"] != "parent-workspace" {
+				t.Errorf("expected the fork of parent-workspace/parent-repo, got %#v", state.Attributes)
+			}
+		},
+	})
+}
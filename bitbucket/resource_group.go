@@ -1,40 +1,39 @@
 package bitbucket
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"net/http"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
-type UserGroup struct {
-	Name                    string `json:"name,omitempty"`
-	Slug                    string `json:"slug,omitempty"`
-	AutoAdd                 bool   `json:"auto_add,omitempty"`
-	Permission              string `json:"permission,omitempty"`
-	EmailForwardingDisabled bool   `json:"email_forwarding_disabled,omitempty"`
-}
-
 func resourceGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGroupsCreate,
-		Read:   resourceGroupsRead,
-		Update: resourceGroupsUpdate,
-		Delete: resourceGroupsDelete,
+		CreateContext: resourceGroupsCreate,
+		ReadContext:   resourceGroupsRead,
+		UpdateContext: resourceGroupsUpdate,
+		DeleteContext: resourceGroupsDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
 			"workspace": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"name": {
@@ -62,70 +61,54 @@ func resourceGroup() *schema.Resource {
 	}
 }
 
-func resourceGroupsCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceGroupsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
 	group := expandGroup(d)
 	log.Printf("[DEBUG] Group Request: %#v", group)
 
-	workspace := d.Get("workspace").(string)
-	body := []byte(fmt.Sprintf("name=%s", group.Name))
-	groupReq, err := client.PostNonJson(fmt.Sprintf("1.0/groups/%s", workspace), bytes.NewBuffer(body))
+	workspace, err := resolveWorkspace(d, m, "workspace")
 	if err != nil {
-		return err
-	}
-
-	body, readerr := ioutil.ReadAll(groupReq.Body)
-	if readerr != nil {
-		return readerr
+		return apiDiagnostics(d, err)
 	}
-
-	log.Printf("[DEBUG] Group Req Response JSON: %v", string(body))
-
-	decodeerr := json.Unmarshal(body, &group)
-	if decodeerr != nil {
-		return decodeerr
+	group, err = api.Groups.Create(ctx, workspace, group.Name)
+	if err != nil {
+		return apiDiagnostics(d, err)
 	}
 
 	log.Printf("[DEBUG] Group Req Response Decoded: %#v", group)
 
 	d.SetId(string(fmt.Sprintf("%s/%s", workspace, group.Slug)))
 
-	return resourceGroupsRead(d, m)
+	err = waitForObject(ctx, d.Timeout(schema.TimeoutCreate), fmt.Sprintf("Group (%s)", d.Id()), func() (bool, error) {
+		_, err := api.Groups.Get(ctx, workspace, group.Slug)
+		return err == nil, err
+	})
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	return resourceGroupsRead(ctx, d, m)
 }
 
-func resourceGroupsRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
 	workspace, slug, err := groupId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	groupsReq, _ := client.Get(fmt.Sprintf("1.0/groups/%s/%s", workspace, slug))
+	grp, err := api.Groups.Get(ctx, workspace, slug)
 
-	if groupsReq.StatusCode == http.StatusNotFound {
+	if IsNotFound(err) {
 		log.Printf("[WARN] Group (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
-	if groupsReq.Body == nil {
-		return fmt.Errorf("error reading Group (%s): empty response", d.Id())
-	}
-
-	var grp *UserGroup
-
-	body, readerr := ioutil.ReadAll(groupsReq.Body)
-	if readerr != nil {
-		return readerr
-	}
-
-	log.Printf("[DEBUG] Groups Response JSON: %v", string(body))
-
-	decodeerr := json.Unmarshal(body, &grp)
-	if decodeerr != nil {
-		return decodeerr
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Group (%s): %w", d.Id(), err))
 	}
 
 	log.Printf("[DEBUG] Groups Response Decoded: %#v", grp)
@@ -140,46 +123,39 @@ func resourceGroupsRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceGroupsUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceGroupsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
 	group := expandGroup(d)
 	log.Printf("[DEBUG] Group Request: %#v", group)
-	bytedata, err := json.Marshal(group)
 
-	if err != nil {
-		return err
-	}
-
-	_, err = client.Put(fmt.Sprintf("1.0/groups/%s/%s/",
-		d.Get("workspace").(string), d.Get("slug").(string)), bytes.NewBuffer(bytedata))
+	err := api.Groups.Update(ctx, d.Get("workspace").(string), d.Get("slug").(string), group)
 
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	return resourceGroupsRead(d, m)
+	return resourceGroupsRead(ctx, d, m)
 }
 
-func resourceGroupsDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceGroupsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
 	workspace, slug, err := groupId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	_, err = client.Delete(fmt.Sprintf("1.0/groups/%s/%s", workspace, slug))
-
-	if err != nil {
-		return err
+	err = api.Groups.Delete(ctx, workspace, slug)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Group (%s): %w", d.Id(), err))
 	}
 
-	return err
+	return nil
 }
 
-func expandGroup(d *schema.ResourceData) *UserGroup {
-	group := &UserGroup{
+func expandGroup(d *schema.ResourceData) *bitbucketapi.UserGroup {
+	group := &bitbucketapi.UserGroup{
 		Name: d.Get("name").(string),
 	}
 
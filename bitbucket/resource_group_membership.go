@@ -1,33 +1,36 @@
 package bitbucket
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"net/http"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
-type UserGroupMembership struct {
-	UUID string `json:"uuid,omitempty"`
-}
-
 func resourceGroupMembership() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGroupMembershipsPut,
-		Read:   resourceGroupMembershipsRead,
-		Delete: resourceGroupMembershipsDelete,
+		CreateContext: resourceGroupMembershipsPut,
+		ReadContext:   resourceGroupMembershipsRead,
+		DeleteContext: resourceGroupMembershipsDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
 			"workspace": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"group_slug": {
@@ -48,65 +51,48 @@ func resourceGroupMembership() *schema.Resource {
 	}
 }
 
-func resourceGroupMembershipsPut(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceGroupMembershipsPut(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
-	workspace := d.Get("workspace").(string)
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
 	groupSlug := d.Get("group_slug").(string)
 	uuid := d.Get("uuid").(string)
 
-	_, err := client.PutOnly(fmt.Sprintf("1.0/groups/%s/%s/members/%s",
-		workspace, groupSlug, uuid))
+	err = api.Groups.AddMember(ctx, workspace, groupSlug, uuid)
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
 	d.SetId(string(fmt.Sprintf("%s/%s/%s", workspace, groupSlug, uuid)))
 
-	return resourceGroupMembershipsRead(d, m)
+	return resourceGroupMembershipsRead(ctx, d, m)
 }
 
-func resourceGroupMembershipsRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceGroupMembershipsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
 	workspace, slug, uuid, err := groupMemberId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	groupsReq, _ := client.Get(fmt.Sprintf("1.0/groups/%s/%s/members", workspace, slug))
-
-	if groupsReq.StatusCode == http.StatusNotFound {
+	members, err := api.Groups.Members(ctx, workspace, slug)
+	if IsNotFound(err) {
 		log.Printf("[WARN] Group Membership (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
-	if groupsReq.Body == nil {
-		return fmt.Errorf("error reading Group Membership (%s): empty response", d.Id())
-	}
-
-	var members []*UserGroupMembership
-
-	body, readerr := ioutil.ReadAll(groupsReq.Body)
-	if readerr != nil {
-		return readerr
-	}
-
-	log.Printf("[DEBUG] Group Membership Response JSON: %v", string(body))
-
-	decodeerr := json.Unmarshal(body, &members)
-	if decodeerr != nil {
-		return decodeerr
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Group Membership (%s): %w", d.Id(), err))
 	}
 
 	log.Printf("[DEBUG] Group Membership Response Decoded: %#v", members)
 
-	if len(members) == 0 {
-		return fmt.Errorf("error getting Group Members (%s): empty response", d.Id())
-	}
-
-	var member *UserGroupMembership
+	var member *bitbucketapi.UserGroupMembership
 	for _, mbr := range members {
 		if mbr.UUID == uuid {
 			member = mbr
@@ -115,7 +101,9 @@ func resourceGroupMembershipsRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if member == nil {
-		return fmt.Errorf("error getting Group Member (%s): not found", d.Id())
+		log.Printf("[WARN] Group Membership (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
 	}
 
 	log.Printf("[DEBUG] Group Member Response Decoded: %#v", member)
@@ -127,22 +115,20 @@ func resourceGroupMembershipsRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceGroupMembershipsDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceGroupMembershipsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
 	workspace, slug, uuid, err := groupMemberId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	_, err = client.Delete(fmt.Sprintf("1.0/groups/%s/%s/members/%s",
-		workspace, slug, uuid))
-
-	if err != nil {
-		return err
+	err = api.Groups.RemoveMember(ctx, workspace, slug, uuid)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Group Membership (%s): %w", d.Id(), err))
 	}
 
-	return err
+	return nil
 }
 
 func groupMemberId(id string) (string, string, string, error) {
@@ -1,6 +1,7 @@
 package bitbucket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -9,18 +10,19 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
 func TestAccBitbucketGroupMembership_basic(t *testing.T) {
-	var group UserGroup
+	random := testAccCassette(t)
+	var group bitbucketapi.UserGroup
 	resourceName := "bitbucket_group_membership.test"
 	grpResourceName := "bitbucket_group.test"
 
 	workspace := os.Getenv("BITBUCKET_TEAM")
-	rName := acctest.RandomWithPrefix("tf-test")
+	rName := random.name("tf-test")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -57,7 +59,7 @@ func testAccCheckBitbucketGroupMembershipDestroy(s *terraform.State) error {
 			return err
 		}
 
-		response, _ := client.Get(fmt.Sprintf("1.0/groups/%s/%s/members",
+		response, _ := client.Get(context.Background(), fmt.Sprintf("1.0/groups/%s/%s/members",
 			workspace, slug))
 
 		if response.StatusCode == http.StatusNotFound {
@@ -68,7 +70,7 @@ func testAccCheckBitbucketGroupMembershipDestroy(s *terraform.State) error {
 			return err
 		}
 
-		var members []*UserGroupMembership
+		var members []*bitbucketapi.UserGroupMembership
 		body, readerr := ioutil.ReadAll(response.Body)
 		if readerr != nil {
 			return readerr
@@ -83,7 +85,7 @@ func testAccCheckBitbucketGroupMembershipDestroy(s *terraform.State) error {
 
 		log.Printf("[DEBUG] Group Membership Response Test Decoded: %#v", members)
 
-		var member *UserGroupMembership
+		var member *bitbucketapi.UserGroupMembership
 		for _, mbr := range members {
 			if mbr.UUID == uuid {
 				member = mbr
@@ -98,7 +100,7 @@ func testAccCheckBitbucketGroupMembershipDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckBitbucketGroupMembershipExists(n string, group *UserGroup) resource.TestCheckFunc {
+func testAccCheckBitbucketGroupMembershipExists(n string, group *bitbucketapi.UserGroup) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
@@ -131,3 +133,16 @@ resource "bitbucket_group_membership" "test" {
 }
 `, workspace, rName)
 }
+
+func TestBitbucketGroupMembership_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seed("1.0/groups/fake-workspace/offline-group", map[string]interface{}{"name": "Offline Group", "slug": "offline-group"})
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_group_membership",
+		steps: []map[string]interface{}{
+			{"group_slug": "offline-group", "uuid": "{member}"},
+		},
+		importID: func(state *terraform.InstanceState) string { return state.ID },
+	})
+}
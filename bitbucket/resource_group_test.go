@@ -1,6 +1,7 @@
 package bitbucket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -9,17 +10,18 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
 func TestAccBitbucketGroup_basic(t *testing.T) {
-	var group UserGroup
+	random := testAccCassette(t)
+	var group bitbucketapi.UserGroup
 	resourceName := "bitbucket_group.test"
 
 	workspace := os.Getenv("BITBUCKET_TEAM")
-	rName := acctest.RandomWithPrefix("tf-test")
+	rName := random.name("tf-test")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -64,7 +66,7 @@ func testAccCheckBitbucketGroupDestroy(s *terraform.State) error {
 			continue
 		}
 
-		response, err := client.Get(fmt.Sprintf("1.0/groups/%s/%s",
+		response, err := client.Get(context.Background(), fmt.Sprintf("1.0/groups/%s/%s",
 			rs.Primary.Attributes["workspace"], rs.Primary.Attributes["slug"]))
 
 		if response.StatusCode == http.StatusNotFound {
@@ -75,7 +77,7 @@ func testAccCheckBitbucketGroupDestroy(s *terraform.State) error {
 			return err
 		}
 
-		var group *UserGroup
+		var group *bitbucketapi.UserGroup
 		body, readerr := ioutil.ReadAll(response.Body)
 		if readerr != nil {
 			return readerr
@@ -98,7 +100,7 @@ func testAccCheckBitbucketGroupDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckBitbucketGroupExists(n string, group *UserGroup) resource.TestCheckFunc {
+func testAccCheckBitbucketGroupExists(n string, group *bitbucketapi.UserGroup) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
@@ -139,3 +141,31 @@ resource "bitbucket_group" "test" {
 }
 `, workspace, rName)
 }
+
+func TestBitbucketGroup_offline(t *testing.T) {
+	testOfflineLifecycle(t, newFakeBitbucket(t), offlineLifecycle{
+		resource: "bitbucket_group",
+		steps: []map[string]interface{}{
+			{"name": "Offline Group"},
+			{"name": "Offline Group", "auto_add": true, "permission": "read"},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if state.ID != "fake-workspace/offline-group" || state.Attributes["slug"] != "offline-group" {
+				t.Errorf("unexpected group %#v", state)
+			}
+		},
+		importID: func(state *terraform.InstanceState) string { return state.ID },
+	})
+}
+
+func TestBitbucketGroup_offlineEventuallyConsistent(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.lag = 2
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_group",
+		steps: []map[string]interface{}{
+			{"name": "Offline Group"},
+		},
+	})
+}
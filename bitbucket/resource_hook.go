@@ -1,37 +1,86 @@
 package bitbucket
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"net/http"
-	"net/url"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
-// Hook is the hook you want to add to a bitbucket repository
-type Hook struct {
-	UUID                 string   `json:"uuid,omitempty"`
-	URL                  string   `json:"url,omitempty"`
-	Description          string   `json:"description,omitempty"`
-	Active               bool     `json:"active"`
-	SkipCertVerification bool     `json:"skip_cert_verification"`
-	Events               []string `json:"events,omitempty"`
+// hookEvents are the events Bitbucket Cloud sends webhooks for
+var hookEvents = []string{
+	"pullrequest:unapproved",
+	"issue:comment_created",
+	"repo:imported",
+	"repo:created",
+	"repo:commit_comment_created",
+	"pullrequest:approved",
+	"pullrequest:comment_updated",
+	"issue:updated",
+	"project:updated",
+	"repo:deleted",
+	"pullrequest:changes_request_created",
+	"pullrequest:comment_created",
+	"repo:commit_status_updated",
+	"pullrequest:updated",
+	"issue:created",
+	"repo:fork",
+	"pullrequest:comment_deleted",
+	"repo:commit_status_created",
+	"repo:updated",
+	"pullrequest:rejected",
+	"pullrequest:fulfilled",
+	"pullrequest:created",
+	"pullrequest:changes_request_removed",
+	"repo:transfer",
+	"repo:push",
+}
+
+// dataCenterHookEvents are the events Bitbucket Data Center sends webhooks for
+var dataCenterHookEvents = []string{
+	"repo:refs_changed",
+	"repo:modified",
+	"repo:forked",
+	"repo:comment:added",
+	"repo:comment:edited",
+	"repo:comment:deleted",
+	"pr:opened",
+	"pr:from_ref_updated",
+	"pr:modified",
+	"pr:reviewer:updated",
+	"pr:reviewer:approved",
+	"pr:reviewer:unapproved",
+	"pr:reviewer:needs_work",
+	"pr:merged",
+	"pr:declined",
+	"pr:deleted",
+	"pr:comment:added",
+	"pr:comment:edited",
+	"pr:comment:deleted",
+	"mirror:repo_synchronized",
 }
 
 func resourceHook() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceHookCreate,
-		Read:   resourceHookRead,
-		Update: resourceHookUpdate,
-		Delete: resourceHookDelete,
+		CreateContext: resourceHookCreate,
+		ReadContext:   resourceHookRead,
+		UpdateContext: resourceHookUpdate,
+		DeleteContext: resourceHookDelete,
+		CustomizeDiff: resourceHookCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idParts := strings.Split(d.Id(), "/")
 				if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected OWNER/REPO/HOOK-ID", d.Id())
@@ -46,7 +95,8 @@ func resourceHook() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"owner": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"repository": {
@@ -76,33 +126,8 @@ func resourceHook() *schema.Resource {
 				Required: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
-					ValidateFunc: validation.StringInSlice([]string{
-						"pullrequest:unapproved",
-						"issue:comment_created",
-						"repo:imported",
-						"repo:created",
-						"repo:commit_comment_created",
-						"pullrequest:approved",
-						"pullrequest:comment_updated",
-						"issue:updated",
-						"project:updated",
-						"repo:deleted",
-						"pullrequest:changes_request_created",
-						"pullrequest:comment_created",
-						"repo:commit_status_updated",
-						"pullrequest:updated",
-						"issue:created",
-						"repo:fork",
-						"pullrequest:comment_deleted",
-						"repo:commit_status_created",
-						"repo:updated",
-						"pullrequest:rejected",
-						"pullrequest:fulfilled",
-						"pullrequest:created",
-						"pullrequest:changes_request_removed",
-						"repo:transfer",
-						"repo:push",
-					}, false),
+					// the events of the other flavor are rejected by resourceHookCustomizeDiff
+					ValidateFunc: validation.StringInSlice(append(append([]string{}, hookEvents...), dataCenterHookEvents...), false),
 				},
 			},
 			"skip_cert_verification": {
@@ -114,7 +139,28 @@ func resourceHook() *schema.Resource {
 	}
 }
 
-func createHook(d *schema.ResourceData) *Hook {
+// resourceHookCustomizeDiff rejects the events the configured flavor of Bitbucket doesn't know
+func resourceHookCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	flavor, events := "Bitbucket Cloud", hookEvents
+	if c, ok := m.(Clients); ok && c.isDataCenter() {
+		flavor, events = "Bitbucket Data Center", dataCenterHookEvents
+	}
+
+	supported := make(map[string]bool, len(events))
+	for _, event := range events {
+		supported[event] = true
+	}
+
+	for _, event := range d.Get("events").(*schema.Set).List() {
+		if !supported[event.(string)] {
+			return fmt.Errorf("event %q is not supported on %s", event, flavor)
+		}
+	}
+
+	return nil
+}
+
+func createHook(d *schema.ResourceData) *bitbucketapi.Hook {
 
 	events := make([]string, 0, len(d.Get("events").(*schema.Set).List()))
 
@@ -122,7 +168,7 @@ func createHook(d *schema.ResourceData) *Hook {
 		events = append(events, item.(string))
 	}
 
-	hook := &Hook{
+	hook := &bitbucketapi.Hook{
 		URL:                  d.Get("url").(string),
 		Description:          d.Get("description").(string),
 		Active:               d.Get("active").(bool),
@@ -133,112 +179,66 @@ func createHook(d *schema.ResourceData) *Hook {
 	return hook
 }
 
-func resourceHookCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
-	hook := createHook(d)
+func resourceHookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
-	payload, err := json.Marshal(hook)
+	owner, err := resolveWorkspace(d, m, "owner")
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	hookReq, err := client.Post(fmt.Sprintf("2.0/repositories/%s/%s/hooks",
-		d.Get("owner").(string),
-		d.Get("repository").(string),
-	), bytes.NewBuffer(payload))
-
+	hook, err := api.Hooks.Create(ctx, owner, d.Get("repository").(string), createHook(d))
 	if err != nil {
-		return err
-	}
-
-	body, readerr := ioutil.ReadAll(hookReq.Body)
-	if readerr != nil {
-		return readerr
-	}
-
-	decodeerr := json.Unmarshal(body, &hook)
-	if decodeerr != nil {
-		return decodeerr
+		return apiDiagnostics(d, err)
 	}
 
 	d.SetId(hook.UUID)
 
-	return resourceHookRead(d, m)
+	return resourceHookRead(ctx, d, m)
 }
-func resourceHookRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceHookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
-	hookReq, err := client.Get(fmt.Sprintf("2.0/repositories/%s/%s/hooks/%s",
-		d.Get("owner").(string),
-		d.Get("repository").(string),
-		url.PathEscape(d.Id()),
-	))
+	hook, err := api.Hooks.Get(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
 
-	if hookReq.StatusCode == http.StatusNotFound {
+	if IsNotFound(err) {
 		log.Printf("[WARN] Repository Hook (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
 	if err != nil {
-		return err
+		return apiDiagnostics(d, fmt.Errorf("error reading Repository Hook (%s): %w", d.Id(), err))
 	}
 
-	log.Printf("ID: %s", url.PathEscape(d.Id()))
-
-	if hookReq.StatusCode == 200 {
-		var hook Hook
-
-		body, readerr := ioutil.ReadAll(hookReq.Body)
-		if readerr != nil {
-			return readerr
-		}
-
-		decodeerr := json.Unmarshal(body, &hook)
-		if decodeerr != nil {
-			return decodeerr
-		}
-
-		d.Set("uuid", hook.UUID)
-		d.Set("description", hook.Description)
-		d.Set("active", hook.Active)
-		d.Set("url", hook.URL)
-		d.Set("skip_cert_verification", hook.SkipCertVerification)
-		d.Set("events", hook.Events)
-	}
+	d.Set("uuid", hook.UUID)
+	d.Set("description", hook.Description)
+	d.Set("active", hook.Active)
+	d.Set("url", hook.URL)
+	d.Set("skip_cert_verification", hook.SkipCertVerification)
+	d.Set("events", hook.Events)
 
 	return nil
 }
 
-func resourceHookUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
-	hook := createHook(d)
-	payload, err := json.Marshal(hook)
-	if err != nil {
-		return err
-	}
-
-	_, err = client.Put(fmt.Sprintf("2.0/repositories/%s/%s/hooks/%s",
-		d.Get("owner").(string),
-		d.Get("repository").(string),
-		url.PathEscape(d.Id()),
-	), bytes.NewBuffer(payload))
+func resourceHookUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
+	_, err := api.Hooks.Update(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id(), createHook(d))
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	return resourceHookRead(d, m)
+	return resourceHookRead(ctx, d, m)
 }
 
-func resourceHookDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
-	_, err := client.Delete(fmt.Sprintf("2.0/repositories/%s/%s/hooks/%s",
-		d.Get("owner").(string),
-		d.Get("repository").(string),
-		url.PathEscape(d.Id()),
-	))
+func resourceHookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
-	return err
+	err := api.Hooks.Delete(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Repository Hook (%s): %w", d.Id(), err))
+	}
 
+	return nil
 }
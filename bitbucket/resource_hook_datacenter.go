@@ -0,0 +1,90 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Webhooks of Bitbucket Data Center, owner is the key of the project the repository belongs to. The description is
+// sent as the name of the webhook.
+
+func createDataCenterWebhook(d *schema.ResourceData) *DataCenterWebhook {
+	hook := createHook(d)
+
+	return &DataCenterWebhook{
+		Name:                    hook.Description,
+		URL:                     hook.URL,
+		Active:                  hook.Active,
+		Events:                  hook.Events,
+		SslVerificationRequired: !hook.SkipCertVerification,
+	}
+}
+
+func resourceHookDataCenterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	owner, err := resolveWorkspace(d, m, "owner")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	hook, err := client.CreateWebhook(ctx, owner, d.Get("repository").(string), createDataCenterWebhook(d))
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error creating Repository Hook: %w", err))
+	}
+
+	d.SetId(strconv.Itoa(hook.ID))
+
+	return resourceHookDataCenterRead(ctx, d, m)
+}
+
+func resourceHookDataCenterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	hook, err := client.GetWebhook(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if IsNotFound(err) {
+		log.Printf("[WARN] Repository Hook (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Repository Hook (%s): %w", d.Id(), err))
+	}
+
+	d.Set("uuid", strconv.Itoa(hook.ID))
+	d.Set("description", hook.Name)
+	d.Set("active", hook.Active)
+	d.Set("url", hook.URL)
+	d.Set("skip_cert_verification", !hook.SslVerificationRequired)
+	d.Set("events", hook.Events)
+
+	return nil
+}
+
+func resourceHookDataCenterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	_, err := client.UpdateWebhook(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id(), createDataCenterWebhook(d))
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error updating Repository Hook (%s): %w", d.Id(), err))
+	}
+
+	return resourceHookDataCenterRead(ctx, d, m)
+}
+
+func resourceHookDataCenterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	err := client.DeleteWebhook(ctx, d.Get("owner").(string), d.Get("repository").(string), d.Id())
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Repository Hook (%s): %w", d.Id(), err))
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package bitbucket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,17 +10,19 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	uuid "github.com/satori/go.uuid"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
 func TestAccBitbucketHook_basic(t *testing.T) {
-	var hook Hook
+	random := testAccCassette(t)
+	var hook bitbucketapi.Hook
 	resourceName := "bitbucket_hook.test"
 	testUser := os.Getenv("BITBUCKET_TEAM")
-	rName := acctest.RandomWithPrefix("tf-test")
+	rName := random.name("tf-test")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -73,7 +76,7 @@ func TestAccBitbucketHook_basic(t *testing.T) {
 }
 
 func TestEncodesJsonCompletely(t *testing.T) {
-	hook := &Hook{
+	hook := &bitbucketapi.Hook{
 		UUID:        uuid.NewV4().String(),
 		URL:         "https://site.internal/",
 		Description: "Test description",
@@ -106,7 +109,7 @@ func testAccCheckBitbucketHookDestroy(s *terraform.State) error {
 			continue
 		}
 
-		response, err := client.Get(fmt.Sprintf("2.0/repositories/%s/%s/hooks/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"], url.PathEscape(rs.Primary.Attributes["uuid"])))
+		response, err := client.Get(context.Background(), fmt.Sprintf("2.0/repositories/%s/%s/hooks/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"], url.PathEscape(rs.Primary.Attributes["uuid"])))
 
 		if err == nil {
 			return fmt.Errorf("The resource was found should have errored")
@@ -120,7 +123,7 @@ func testAccCheckBitbucketHookDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckBitbucketHookExists(n string, hook *Hook) resource.TestCheckFunc {
+func testAccCheckBitbucketHookExists(n string, hook *bitbucketapi.Hook) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
@@ -183,3 +186,75 @@ func testAccBitbucketHookImportStateIdFunc(resourceName string) resource.ImportS
 		return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["owner"], rs.Primary.Attributes["repository"], rs.Primary.ID), nil
 	}
 }
+
+func TestBitbucketHook_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_hook",
+		steps: []map[string]interface{}{
+			{"repository": "offline-repo", "url": "https://example.com/hook", "description": "offline", "events": []interface{}{"repo:push"}},
+			{"repository": "offline-repo", "url": "https://example.com/hook", "description": "updated", "events": []interface{}{"repo:push", "issue:created"}, "active": false},
+		},
+		importID: func(state *terraform.InstanceState) string {
+			return fmt.Sprintf("fake-workspace/offline-repo/%s", state.ID)
+		},
+	})
+}
+
+func TestBitbucketHook_offlineDataCenter(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedDataCenterRepository("PROJ", "offline-repo")
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_hook",
+		provider: testDataCenterProvider,
+		steps: []map[string]interface{}{
+			{"owner": "PROJ", "repository": "offline-repo", "url": "https://example.com/hook", "description": "offline", "events": []interface{}{"repo:refs_changed"}},
+			{"owner": "PROJ", "repository": "offline-repo", "url": "https://example.com/hook", "description": "updated", "events": []interface{}{"repo:refs_changed", "pr:opened"}, "skip_cert_verification": false},
+		},
+		importID: func(state *terraform.InstanceState) string {
+			return fmt.Sprintf("PROJ/offline-repo/%s", state.ID)
+		},
+	})
+}
+
+func TestBitbucketHook_offlineInvalidURL(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+
+	diags := testOfflineApplyError(t, f, "bitbucket_hook", map[string]interface{}{
+		"repository": "offline-repo", "url": "example.com/hook", "description": "offline", "events": []interface{}{"repo:push"},
+	})
+
+	if len(diags) != 2 || diags[1].Summary != "url: Enter a valid URL." || !diags[1].AttributePath.Equals(cty.GetAttrPath("url")) {
+		t.Errorf("expected the error to be attached to url, got %#v", diags)
+	}
+}
+
+func TestBitbucketHook_offlineFlavorEvents(t *testing.T) {
+	f := newFakeBitbucket(t)
+	r := Provider().ResourcesMap["bitbucket_hook"]
+
+	for _, tc := range []struct {
+		provider map[string]interface{}
+		event    string
+		valid    bool
+	}{
+		{nil, "repo:push", true},
+		{nil, "pr:opened", false},
+		{testDataCenterProvider, "repo:refs_changed", true},
+		{testDataCenterProvider, "pullrequest:created", false},
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"repository": "offline-repo", "url": "https://example.com/hook", "description": "offline",
+			"events": []interface{}{tc.event},
+		})
+
+		_, err := r.Diff(context.Background(), nil, config, f.providerMeta(t, tc.provider))
+		if (err == nil) != tc.valid {
+			t.Errorf("event %s: expected valid to be %t, got %v", tc.event, tc.valid, err)
+		}
+	}
+}
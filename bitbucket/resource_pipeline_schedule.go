@@ -1,30 +1,39 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"strings"
+	"time"
 
 	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePipelineSchedule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePipelineScheduleCreate,
-		Read:   resourcePipelineScheduleRead,
-		Update: resourcePipelineScheduleUpdate,
-		Delete: resourcePipelineScheduleDelete,
+		CreateContext: resourcePipelineScheduleCreate,
+		ReadContext:   resourcePipelineScheduleRead,
+		UpdateContext: resourcePipelineScheduleUpdate,
+		DeleteContext: resourcePipelineScheduleDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
 			"workspace": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"repository": {
@@ -85,7 +94,7 @@ func resourcePipelineSchedule() *schema.Resource {
 	}
 }
 
-func resourcePipelineScheduleCreate(d *schema.ResourceData, m interface{}) error {
+func resourcePipelineScheduleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
@@ -93,60 +102,63 @@ func resourcePipelineScheduleCreate(d *schema.ResourceData, m interface{}) error
 	log.Printf("[DEBUG] Pipeline Schedule Request: %#v", pipeSchedule)
 
 	repo := d.Get("repository").(string)
-	workspace := d.Get("workspace").(string)
-	schedule, _, err := pipeApi.CreateRepositoryPipelineSchedule(c.AuthContext, *pipeSchedule, workspace, repo)
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+	schedule, _, err := pipeApi.CreateRepositoryPipelineSchedule(c.withAuth(ctx), *pipeSchedule, workspace, repo)
 
 	if err != nil {
-		return fmt.Errorf("error creating pipeline schedule: %w", err)
+		return apiDiagnostics(d, fmt.Errorf("error creating pipeline schedule: %w", err))
 	}
 
 	d.SetId(string(fmt.Sprintf("%s/%s/%s", workspace, repo, schedule.Uuid)))
 
-	return resourcePipelineScheduleRead(d, m)
+	return resourcePipelineScheduleRead(ctx, d, m)
 }
 
-func resourcePipelineScheduleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePipelineScheduleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
 	workspace, repo, uuid, err := pipeScheduleId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
 	pipeSchedule := expandPipelineSchedule(d)
 	log.Printf("[DEBUG] Pipeline Schedule Request: %#v", pipeSchedule)
-	_, _, err = pipeApi.UpdateRepositoryPipelineSchedule(c.AuthContext, *pipeSchedule, workspace, repo, uuid)
+	_, _, err = pipeApi.UpdateRepositoryPipelineSchedule(c.withAuth(ctx), *pipeSchedule, workspace, repo, uuid)
 
 	if err != nil {
-		return fmt.Errorf("error updating pipeline schedule: %w", err)
+		return apiDiagnostics(d, fmt.Errorf("error updating pipeline schedule: %w", err))
 	}
 
-	return resourcePipelineScheduleRead(d, m)
+	return resourcePipelineScheduleRead(ctx, d, m)
 }
 
-func resourcePipelineScheduleRead(d *schema.ResourceData, m interface{}) error {
+func resourcePipelineScheduleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
 	workspace, repo, uuid, err := pipeScheduleId(d.Id())
 	if err != nil {
-		return err
-	}
-
-	schedule, res, err := pipeApi.GetRepositoryPipelineSchedule(c.AuthContext, workspace, repo, uuid)
-	if err != nil {
-		return fmt.Errorf("error reading Pipeline Schedule (%s): %w", d.Id(), err)
+		return apiDiagnostics(d, err)
 	}
 
-	if res.StatusCode == http.StatusNotFound {
+	schedule, res, err := pipeApi.GetRepositoryPipelineSchedule(c.withAuth(ctx), workspace, repo, uuid)
+	if IsNotFound(err) {
 		log.Printf("[WARN] Pipeline Schedule (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Pipeline Schedule (%s): %w", d.Id(), err))
+	}
+
 	if res.Body == nil {
-		return fmt.Errorf("error getting Pipeline Schedule (%s): empty response", d.Id())
+		return diag.Errorf("error getting Pipeline Schedule (%s): empty response", d.Id())
 	}
 
 	d.Set("repository", repo)
@@ -160,21 +172,20 @@ func resourcePipelineScheduleRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourcePipelineScheduleDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePipelineScheduleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
 	workspace, repo, uuid, err := pipeScheduleId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
-	_, err = pipeApi.DeleteRepositoryPipelineSchedule(c.AuthContext, workspace, repo, uuid)
-
-	if err != nil {
-		return fmt.Errorf("error deleting Pipeline Schedule (%s): %w", d.Id(), err)
+	_, err = pipeApi.DeleteRepositoryPipelineSchedule(c.withAuth(ctx), workspace, repo, uuid)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Pipeline Schedule (%s): %w", d.Id(), err))
 	}
 
-	return err
+	return nil
 }
 
 func expandPipelineSchedule(d *schema.ResourceData) *bitbucket.PipelineSchedule {
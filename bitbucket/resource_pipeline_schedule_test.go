@@ -11,6 +11,7 @@ import (
 )
 
 func TestAccBitbucketPipelineSchedule_basic(t *testing.T) {
+	testAccCassette(t)
 	resourceName := "bitbucket_pipeline_schedule.test"
 
 	workspace := os.Getenv("BITBUCKET_TEAM")
@@ -110,3 +111,36 @@ resource "bitbucket_pipeline_schedule" "test" {
 }
 `, workspace, repo, enabled)
 }
+
+func TestBitbucketPipelineSchedule_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+
+	schedule := func(enabled bool) map[string]interface{} {
+		return map[string]interface{}{
+			"workspace":    "fake-workspace",
+			"repository":   "offline-repo",
+			"enabled":      enabled,
+			"cron_pattern": "0 30 * * * ? *",
+			"target": []interface{}{map[string]interface{}{
+				"ref_name": "master",
+				"ref_type": "branch",
+				"selector": []interface{}{map[string]interface{}{"pattern": "staging"}},
+			}},
+		}
+	}
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_pipeline_schedule",
+		steps:    []map[string]interface{}{schedule(true), schedule(false)},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if state.ID != "fake-workspace/offline-repo/"+state.Attributes["uuid"] {
+				t.Errorf("expected the schedule to be identified by its uuid, got %q", state.ID)
+			}
+			if expected := fmt.Sprint(step == 0); state.Attributes["enabled"] != expected {
+				t.Errorf("step %d: expected enabled %s, got %#v", step, expected, state.Attributes)
+			}
+		},
+		importID: func(state *terraform.InstanceState) string { return state.ID },
+	})
+}
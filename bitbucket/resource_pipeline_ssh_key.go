@@ -1,29 +1,38 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"strings"
+	"time"
 
 	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourcePipelineSshKey() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePipelineSshKeysPut,
-		Read:   resourcePipelineSshKeysRead,
-		Update: resourcePipelineSshKeysPut,
-		Delete: resourcePipelineSshKeysDelete,
+		CreateContext: resourcePipelineSshKeysPut,
+		ReadContext:   resourcePipelineSshKeysRead,
+		UpdateContext: resourcePipelineSshKeysPut,
+		DeleteContext: resourcePipelineSshKeysDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
 			"workspace": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"repository": {
@@ -43,48 +52,50 @@ func resourcePipelineSshKey() *schema.Resource {
 	}
 }
 
-func resourcePipelineSshKeysPut(d *schema.ResourceData, m interface{}) error {
+func resourcePipelineSshKeysPut(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
 	pipeSshKey := expandPipelineSshKey(d)
-	log.Printf("[DEBUG] Pipeline Ssh Key Request: %#v", pipeSshKey)
 
 	repo := d.Get("repository").(string)
-	workspace := d.Get("workspace").(string)
-	_, _, err := pipeApi.UpdateRepositoryPipelineKeyPair(c.AuthContext, *pipeSshKey, workspace, repo)
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+	_, _, err = pipeApi.UpdateRepositoryPipelineKeyPair(c.withAuth(ctx), *pipeSshKey, workspace, repo)
 
 	if err != nil {
-		return fmt.Errorf("error creating pipeline ssh key: %w", err)
+		return apiDiagnostics(d, fmt.Errorf("error creating pipeline ssh key: %w", err))
 	}
 
 	d.SetId(string(fmt.Sprintf("%s/%s", workspace, repo)))
 
-	return resourcePipelineSshKeysRead(d, m)
+	return resourcePipelineSshKeysRead(ctx, d, m)
 }
 
-func resourcePipelineSshKeysRead(d *schema.ResourceData, m interface{}) error {
+func resourcePipelineSshKeysRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
 	workspace, repo, err := pipeSshKeyId(d.Id())
 	if err != nil {
-		return err
-	}
-
-	key, res, err := pipeApi.GetRepositoryPipelineSshKeyPair(c.AuthContext, workspace, repo)
-	if err != nil {
-		return fmt.Errorf("error reading Pipeline Ssh Key (%s): %w", d.Id(), err)
+		return apiDiagnostics(d, err)
 	}
 
-	if res.StatusCode == http.StatusNotFound {
+	key, res, err := pipeApi.GetRepositoryPipelineSshKeyPair(c.withAuth(ctx), workspace, repo)
+	if IsNotFound(err) {
 		log.Printf("[WARN] Pipeline Ssh Key (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Pipeline Ssh Key (%s): %w", d.Id(), err))
+	}
+
 	if res.Body == nil {
-		return fmt.Errorf("error getting Pipeline Ssh Key (%s): empty response", d.Id())
+		return diag.Errorf("error getting Pipeline Ssh Key (%s): empty response", d.Id())
 	}
 
 	d.Set("repository", repo)
@@ -95,22 +106,21 @@ func resourcePipelineSshKeysRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourcePipelineSshKeysDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePipelineSshKeysDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
 	workspace, repo, err := pipeSshKeyId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	_, err = pipeApi.DeleteRepositoryPipelineKeyPair(c.AuthContext, workspace, repo)
-
-	if err != nil {
-		return fmt.Errorf("error deleting Pipeline Ssh Key (%s): %w", d.Id(), err)
+	_, err = pipeApi.DeleteRepositoryPipelineKeyPair(c.withAuth(ctx), workspace, repo)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Pipeline Ssh Key (%s): %w", d.Id(), err))
 	}
 
-	return err
+	return nil
 }
 
 func expandPipelineSshKey(d *schema.ResourceData) *bitbucket.PipelineSshKeyPair {
@@ -6,23 +6,23 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccBitbucketPipelineSshKey_basic(t *testing.T) {
+	random := testAccCassette(t)
 	resourceName := "bitbucket_pipeline_ssh_key.test"
 
-	rName := acctest.RandomWithPrefix("tf-test")
+	rName := random.name("tf-test")
 	owner := os.Getenv("BITBUCKET_TEAM")
 	userEmail := os.Getenv("BITBUCKET_USERNAME")
-	publicKey, privateKey, err := RandSSHKeyPairSize(2048, userEmail)
+	publicKey, privateKey, err := random.sshKeyPair(2048, userEmail)
 	if err != nil {
 		t.Fatalf("error generating random SSH key: %s", err)
 	}
 
-	publicKey2, privateKey2, err := RandSSHKeyPairSize(2048, userEmail)
+	publicKey2, privateKey2, err := random.sshKeyPair(2048, userEmail)
 	if err != nil {
 		t.Fatalf("error generating random SSH key: %s", err)
 	}
@@ -117,3 +117,18 @@ resource "bitbucket_pipeline_ssh_key" "test" {
 }
 `, workspace, rName, pubKey, privKey)
 }
+
+func TestBitbucketPipelineSshKey_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_pipeline_ssh_key",
+		steps: []map[string]interface{}{
+			{"repository": "offline-repo", "public_key": "ssh-ed25519 AAAA one", "private_key": "private one"},
+			{"repository": "offline-repo", "public_key": "ssh-ed25519 AAAA two", "private_key": "private two"},
+		},
+		importID:     func(state *terraform.InstanceState) string { return state.ID },
+		importIgnore: []string{"private_key"},
+	})
+}
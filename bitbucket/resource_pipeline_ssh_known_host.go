@@ -1,30 +1,39 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"strings"
+	"time"
 
 	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePipelineSshKnownHost() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePipelineSshKnownHostsCreate,
-		Read:   resourcePipelineSshKnownHostsRead,
-		Update: resourcePipelineSshKnownHostsUpdate,
-		Delete: resourcePipelineSshKnownHostsDelete,
+		CreateContext: resourcePipelineSshKnownHostsCreate,
+		ReadContext:   resourcePipelineSshKnownHostsRead,
+		UpdateContext: resourcePipelineSshKnownHostsUpdate,
+		DeleteContext: resourcePipelineSshKnownHostsDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
 			"workspace": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"repository": {
@@ -70,7 +79,7 @@ func resourcePipelineSshKnownHost() *schema.Resource {
 	}
 }
 
-func resourcePipelineSshKnownHostsCreate(d *schema.ResourceData, m interface{}) error {
+func resourcePipelineSshKnownHostsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
@@ -78,60 +87,63 @@ func resourcePipelineSshKnownHostsCreate(d *schema.ResourceData, m interface{})
 	log.Printf("[DEBUG] Pipeline Ssh Key Request: %#v", pipeSshKnownHost)
 
 	repo := d.Get("repository").(string)
-	workspace := d.Get("workspace").(string)
-	host, _, err := pipeApi.CreateRepositoryPipelineKnownHost(c.AuthContext, *pipeSshKnownHost, workspace, repo)
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+	host, _, err := pipeApi.CreateRepositoryPipelineKnownHost(c.withAuth(ctx), *pipeSshKnownHost, workspace, repo)
 
 	if err != nil {
-		return fmt.Errorf("error creating pipeline ssh known host: %w", err)
+		return apiDiagnostics(d, fmt.Errorf("error creating pipeline ssh known host: %w", err))
 	}
 
 	d.SetId(string(fmt.Sprintf("%s/%s/%s", workspace, repo, host.Uuid)))
 
-	return resourcePipelineSshKnownHostsRead(d, m)
+	return resourcePipelineSshKnownHostsRead(ctx, d, m)
 }
 
-func resourcePipelineSshKnownHostsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePipelineSshKnownHostsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
 	workspace, repo, uuid, err := pipeSshKnownHostId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
 	pipeSshKnownHost := expandPipelineSshKnownHost(d)
 	log.Printf("[DEBUG] Pipeline Ssh Key Request: %#v", pipeSshKnownHost)
-	_, _, err = pipeApi.UpdateRepositoryPipelineKnownHost(c.AuthContext, *pipeSshKnownHost, workspace, repo, uuid)
+	_, _, err = pipeApi.UpdateRepositoryPipelineKnownHost(c.withAuth(ctx), *pipeSshKnownHost, workspace, repo, uuid)
 
 	if err != nil {
-		return fmt.Errorf("error updating pipeline ssh known host: %w", err)
+		return apiDiagnostics(d, fmt.Errorf("error updating pipeline ssh known host: %w", err))
 	}
 
-	return resourcePipelineSshKnownHostsRead(d, m)
+	return resourcePipelineSshKnownHostsRead(ctx, d, m)
 }
 
-func resourcePipelineSshKnownHostsRead(d *schema.ResourceData, m interface{}) error {
+func resourcePipelineSshKnownHostsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
 	workspace, repo, uuid, err := pipeSshKnownHostId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	host, res, err := pipeApi.GetRepositoryPipelineKnownHost(c.AuthContext, workspace, repo, uuid)
-	if err != nil {
-		return fmt.Errorf("error reading Pipeline Ssh known host (%s): %w", d.Id(), err)
-	}
-
-	if res.StatusCode == http.StatusNotFound {
+	host, res, err := pipeApi.GetRepositoryPipelineKnownHost(c.withAuth(ctx), workspace, repo, uuid)
+	if IsNotFound(err) {
 		log.Printf("[WARN] Pipeline Ssh known host (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Pipeline Ssh known host (%s): %w", d.Id(), err))
+	}
+
 	if res.Body == nil {
-		return fmt.Errorf("error getting Pipeline Ssh known host (%s): empty response", d.Id())
+		return diag.Errorf("error getting Pipeline Ssh known host (%s): empty response", d.Id())
 	}
 
 	d.Set("repository", repo)
@@ -143,21 +155,20 @@ func resourcePipelineSshKnownHostsRead(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourcePipelineSshKnownHostsDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePipelineSshKnownHostsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
 	workspace, repo, uuid, err := pipeSshKnownHostId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
-	_, err = pipeApi.DeleteRepositoryPipelineKnownHost(c.AuthContext, workspace, repo, uuid)
-
-	if err != nil {
-		return fmt.Errorf("error deleting Pipeline Ssh known host (%s): %w", d.Id(), err)
+	_, err = pipeApi.DeleteRepositoryPipelineKnownHost(c.withAuth(ctx), workspace, repo, uuid)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Pipeline Ssh known host (%s): %w", d.Id(), err))
 	}
 
-	return err
+	return nil
 }
 
 func expandPipelineSshKnownHost(d *schema.ResourceData) *bitbucket.PipelineKnownHost {
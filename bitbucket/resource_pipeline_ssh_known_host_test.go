@@ -6,15 +6,15 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccBitbucketPipelineSshKnownHost_basic(t *testing.T) {
+	random := testAccCassette(t)
 	resourceName := "bitbucket_pipeline_ssh_known_host.test"
 
-	rName := acctest.RandomWithPrefix("tf-test")
+	rName := random.name("tf-test")
 	owner := os.Getenv("BITBUCKET_TEAM")
 	publicKey, err := RandPlainSSHKeyPairSize(2048)
 	if err != nil {
@@ -125,3 +125,21 @@ resource "bitbucket_pipeline_ssh_known_host" "test" {
 }
 `, workspace, rName, pubKey, host)
 }
+
+func TestBitbucketPipelineSshKnownHost_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+
+	publicKey := func(key string) []interface{} {
+		return []interface{}{map[string]interface{}{"key_type": "ssh-ed25519", "key": key}}
+	}
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_pipeline_ssh_known_host",
+		steps: []map[string]interface{}{
+			{"repository": "offline-repo", "hostname": "example.com", "public_key": publicKey("AAAA one")},
+			{"repository": "offline-repo", "hostname": "example.com", "public_key": publicKey("AAAA two")},
+		},
+		importID: func(state *terraform.InstanceState) string { return state.ID },
+	})
+}
@@ -1,24 +1,32 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"strings"
+	"time"
 
 	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceProject() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceProjectCreate,
-		Update: resourceProjectUpdate,
-		Read:   resourceProjectRead,
-		Delete: resourceProjectDelete,
+		CreateContext: resourceProjectCreate,
+		UpdateContext: resourceProjectUpdate,
+		ReadContext:   resourceProjectRead,
+		DeleteContext: resourceProjectDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -38,7 +46,8 @@ func resourceProject() *schema.Resource {
 			},
 			"owner": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
 			"name": {
@@ -99,7 +108,7 @@ func newProjectFromResource(d *schema.ResourceData) *bitbucket.Project {
 	return project
 }
 
-func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	projectApi := c.ApiClient.ProjectsApi
 	project := newProjectFromResource(d)
@@ -110,16 +119,16 @@ func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 		projectKey = d.Get("key").(string)
 	}
 
-	_, _, err := projectApi.WorkspacesWorkspaceProjectsProjectKeyPut(c.AuthContext, *project, projectKey, d.Get("owner").(string))
+	_, _, err := projectApi.WorkspacesWorkspaceProjectsProjectKeyPut(c.withAuth(ctx), *project, projectKey, d.Get("owner").(string))
 
 	if err != nil {
-		return fmt.Errorf("error updating project (%s): %w", d.Id(), err)
+		return apiDiagnostics(d, fmt.Errorf("error updating project (%s): %w", d.Id(), err))
 	}
 
-	return resourceProjectRead(d, m)
+	return resourceProjectRead(ctx, d, m)
 }
 
-func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
+func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	projectApi := c.ApiClient.ProjectsApi
 	project := newProjectFromResource(d)
@@ -130,19 +139,22 @@ func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 		projectKey = d.Get("key").(string)
 	}
 
-	owner := d.Get("owner").(string)
+	owner, err := resolveWorkspace(d, m, "owner")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
 
-	projRes, _, err := projectApi.WorkspacesWorkspaceProjectsPost(c.AuthContext, *project, owner)
+	projRes, _, err := projectApi.WorkspacesWorkspaceProjectsPost(c.withAuth(ctx), *project, owner)
 	if err != nil {
-		return fmt.Errorf("error creating project (%s): %w", projectKey, err)
+		return apiDiagnostics(d, fmt.Errorf("error creating project (%s): %w", projectKey, err))
 	}
 
 	d.SetId(string(fmt.Sprintf("%s/%s", owner, projRes.Key)))
 
-	return resourceProjectRead(d, m)
+	return resourceProjectRead(ctx, d, m)
 }
 
-func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
+func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id := d.Id()
 	if id != "" {
 		idparts := strings.Split(id, "/")
@@ -150,7 +162,7 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 			d.Set("owner", idparts[0])
 			d.Set("key", idparts[1])
 		} else {
-			return fmt.Errorf("incorrect ID format, should match `owner/key`")
+			return diag.Errorf("incorrect ID format, should match `owner/key`")
 		}
 	}
 
@@ -163,17 +175,18 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 	c := m.(Clients).genClient
 	projectApi := c.ApiClient.ProjectsApi
 
-	projRes, res, err := projectApi.WorkspacesWorkspaceProjectsProjectKeyGet(c.AuthContext, projectKey, d.Get("owner").(string))
+	projRes, _, err := projectApi.WorkspacesWorkspaceProjectsProjectKeyGet(c.withAuth(ctx), projectKey, d.Get("owner").(string))
 
-	if err != nil {
-		return fmt.Errorf("error reading project (%s): %w", d.Id(), err)
-	}
-	if res.StatusCode == http.StatusNotFound {
+	if IsNotFound(err) {
 		log.Printf("[WARN] Project (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading project (%s): %w", d.Id(), err))
+	}
+
 	d.Set("key", projRes.Key)
 	d.Set("is_private", projRes.IsPrivate)
 	d.Set("name", projRes.Name)
@@ -185,7 +198,7 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceProjectDelete(d *schema.ResourceData, m interface{}) error {
+func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var projectKey string
 	projectKey = d.Get("key").(string)
@@ -196,9 +209,9 @@ func resourceProjectDelete(d *schema.ResourceData, m interface{}) error {
 	c := m.(Clients).genClient
 	projectApi := c.ApiClient.ProjectsApi
 
-	_, err := projectApi.WorkspacesWorkspaceProjectsProjectKeyDelete(c.AuthContext, projectKey, d.Get("owner").(string))
-	if err != nil {
-		return fmt.Errorf("error deleting project (%s): %w", d.Id(), err)
+	_, err := projectApi.WorkspacesWorkspaceProjectsProjectKeyDelete(c.withAuth(ctx), projectKey, d.Get("owner").(string))
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting project (%s): %w", d.Id(), err))
 	}
 
 	return nil
@@ -0,0 +1,83 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Projects of Bitbucket Data Center are identified by their key alone, there is no owner.
+
+func newDataCenterProjectFromResource(d *schema.ResourceData) *DataCenterProject {
+	return &DataCenterProject{
+		Key:         d.Get("key").(string),
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Public:      !d.Get("is_private").(bool),
+	}
+}
+
+func resourceProjectDataCenterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	project, err := client.CreateProject(ctx, newDataCenterProjectFromResource(d))
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error creating project (%s): %w", d.Get("key").(string), err))
+	}
+
+	d.SetId(project.Key)
+
+	return resourceProjectDataCenterRead(ctx, d, m)
+}
+
+func resourceProjectDataCenterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	project, err := client.GetProject(ctx, d.Id())
+	if IsNotFound(err) {
+		log.Printf("[WARN] Project (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading project (%s): %w", d.Id(), err))
+	}
+
+	d.Set("key", project.Key)
+	d.Set("name", project.Name)
+	d.Set("description", project.Description)
+	d.Set("is_private", !project.Public)
+	d.Set("uuid", strconv.Itoa(project.ID))
+	d.Set("link", []interface{}{})
+
+	return nil
+}
+
+func resourceProjectDataCenterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	project, err := client.UpdateProject(ctx, d.Id(), newDataCenterProjectFromResource(d))
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error updating project (%s): %w", d.Id(), err))
+	}
+
+	d.SetId(project.Key)
+
+	return resourceProjectDataCenterRead(ctx, d, m)
+}
+
+func resourceProjectDataCenterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	err := client.DeleteProject(ctx, d.Id())
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting project (%s): %w", d.Id(), err))
+	}
+
+	return nil
+}
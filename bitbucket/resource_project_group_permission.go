@@ -0,0 +1,120 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceProjectGroupPermission() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectGroupPermissionPut,
+		ReadContext:   resourceProjectGroupPermissionRead,
+		UpdateContext: resourceProjectGroupPermissionPut,
+		DeleteContext: resourceProjectGroupPermissionDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"project_key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(projectPermissions, false),
+			},
+		},
+	}
+}
+
+func resourceProjectGroupPermissionPut(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+	projectKey := d.Get("project_key").(string)
+	groupSlug := d.Get("group").(string)
+
+	_, err = api.ProjectPermissions.UpdateGroup(ctx, workspace, projectKey, groupSlug, d.Get("permission").(string))
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error granting Project Group Permission (%s/%s/%s): %w", workspace, projectKey, groupSlug, err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", workspace, projectKey, groupSlug))
+
+	return resourceProjectGroupPermissionRead(ctx, d, m)
+}
+
+func resourceProjectGroupPermissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, projectKey, groupSlug, err := projectPermissionId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	permission, err := api.ProjectPermissions.GetGroup(ctx, workspace, projectKey, groupSlug)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Project Group Permission (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Project Group Permission (%s): %w", d.Id(), err))
+	}
+
+	log.Printf("[DEBUG] Project Group Permission Response Decoded: %#v", permission)
+
+	d.Set("workspace", workspace)
+	d.Set("project_key", projectKey)
+	d.Set("group", groupSlug)
+	d.Set("permission", permission.Permission)
+
+	return nil
+}
+
+func resourceProjectGroupPermissionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, projectKey, groupSlug, err := projectPermissionId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	err = api.ProjectPermissions.DeleteGroup(ctx, workspace, projectKey, groupSlug)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error revoking Project Group Permission (%s): %w", d.Id(), err))
+	}
+
+	return nil
+}
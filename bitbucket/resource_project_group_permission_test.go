@@ -0,0 +1,107 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccBitbucketProjectGroupPermission_basic(t *testing.T) {
+	random := testAccCassette(t)
+	resourceName := "bitbucket_project_group_permission.test"
+
+	owner := os.Getenv("BITBUCKET_TEAM")
+	rName := random.name("tf-test")
+	projectKey := random.stringFromCharSet(10, strings.ToUpper(acctest.CharSetAlpha))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketProjectGroupPermissionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketProjectGroupPermissionConfig(owner, rName, projectKey, "write"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "workspace", "bitbucket_project.test", "owner"),
+					resource.TestCheckResourceAttrPair(resourceName, "project_key", "bitbucket_project.test", "key"),
+					resource.TestCheckResourceAttrPair(resourceName, "group", "bitbucket_group.test", "slug"),
+					resource.TestCheckResourceAttr(resourceName, "permission", "write"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccBitbucketProjectGroupPermissionConfig(owner, rName, projectKey, "create-repo"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "permission", "create-repo"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketProjectGroupPermissionDestroy(s *terraform.State) error {
+	api := testAccProvider.Meta().(Clients).api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bitbucket_project_group_permission" {
+			continue
+		}
+
+		workspace, projectKey, groupSlug, err := projectPermissionId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = api.ProjectPermissions.GetGroup(context.Background(), workspace, projectKey, groupSlug)
+		if err == nil {
+			return fmt.Errorf("Project Group Permission still exists")
+		}
+		if !IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccBitbucketProjectGroupPermissionConfig(owner, rName, projectKey, permission string) string {
+	return fmt.Sprintf(`
+resource "bitbucket_project" "test" {
+  owner = %[1]q
+  name  = %[2]q
+  key   = %[3]q
+}
+
+resource "bitbucket_group" "test" {
+  workspace = %[1]q
+  name      = %[2]q
+}
+
+resource "bitbucket_project_group_permission" "test" {
+  workspace   = bitbucket_project.test.owner
+  project_key = bitbucket_project.test.key
+  group       = bitbucket_group.test.slug
+  permission  = %[4]q
+}
+`, owner, rName, projectKey, permission)
+}
+
+func TestBitbucketProjectGroupPermission_offline(t *testing.T) {
+	testOfflineLifecycle(t, newFakeBitbucket(t), offlineLifecycle{
+		resource: "bitbucket_project_group_permission",
+		steps: []map[string]interface{}{
+			{"project_key": "PROJ", "group": "developers", "permission": "write"},
+			{"project_key": "PROJ", "group": "developers", "permission": "create-repo"},
+		},
+		importID: func(state *terraform.InstanceState) string { return "fake-workspace/PROJ/developers" },
+	})
+}
@@ -6,15 +6,15 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccBitbucketProject_basic(t *testing.T) {
+	random := testAccCassette(t)
 	resourceName := "bitbucket_project.test"
 	testTeam := os.Getenv("BITBUCKET_TEAM")
-	rName := acctest.RandomWithPrefix("tf-test")
+	rName := random.name("tf-test")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -47,9 +47,10 @@ func TestAccBitbucketProject_basic(t *testing.T) {
 }
 
 func TestAccBitbucketProject_avatar(t *testing.T) {
+	random := testAccCassette(t)
 	resourceName := "bitbucket_project.test"
 	testTeam := os.Getenv("BITBUCKET_TEAM")
-	rName := acctest.RandomWithPrefix("tf-test")
+	rName := random.name("tf-test")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -135,3 +136,39 @@ func testAccCheckBitbucketProjectExists(n string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func TestBitbucketProject_offline(t *testing.T) {
+	testOfflineLifecycle(t, newFakeBitbucket(t), offlineLifecycle{
+		resource: "bitbucket_project",
+		steps: []map[string]interface{}{
+			{"owner": "fake-workspace", "key": "OFFLINE", "name": "offline"},
+			{"owner": "fake-workspace", "key": "OFFLINE", "name": "offline", "description": "updated", "is_private": false},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if state.ID != "fake-workspace/OFFLINE" {
+				t.Errorf("expected the project to be identified by its owner and key, got %q", state.ID)
+			}
+			if expected := []string{"", "updated"}[step]; state.Attributes["description"] != expected {
+				t.Errorf("step %d: expected the description %q, got %#v", step, expected, state.Attributes)
+			}
+		},
+		importID: func(state *terraform.InstanceState) string { return state.ID },
+	})
+}
+
+func TestBitbucketProject_offlineDataCenter(t *testing.T) {
+	testOfflineLifecycle(t, newFakeBitbucket(t), offlineLifecycle{
+		resource: "bitbucket_project",
+		provider: testDataCenterProvider,
+		steps: []map[string]interface{}{
+			{"key": "OFFLINE", "name": "offline"},
+			{"key": "OFFLINE", "name": "offline", "description": "updated", "is_private": false},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if state.ID != "OFFLINE" || state.Attributes["uuid"] == "" {
+				t.Errorf("expected the project to be identified by its key, got %#v", state.Attributes)
+			}
+		},
+		importID: func(state *terraform.InstanceState) string { return state.ID },
+	})
+}
@@ -0,0 +1,120 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceProjectUserPermission() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectUserPermissionPut,
+		ReadContext:   resourceProjectUserPermissionRead,
+		UpdateContext: resourceProjectUserPermissionPut,
+		DeleteContext: resourceProjectUserPermissionDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"project_key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateUserUUID,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(projectPermissions, false),
+			},
+		},
+	}
+}
+
+func resourceProjectUserPermissionPut(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+	projectKey := d.Get("project_key").(string)
+	user := d.Get("user").(string)
+
+	_, err = api.ProjectPermissions.UpdateUser(ctx, workspace, projectKey, user, d.Get("permission").(string))
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error granting Project User Permission (%s/%s/%s): %w", workspace, projectKey, user, err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", workspace, projectKey, user))
+
+	return resourceProjectUserPermissionRead(ctx, d, m)
+}
+
+func resourceProjectUserPermissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, projectKey, user, err := projectPermissionId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	permission, err := api.ProjectPermissions.GetUser(ctx, workspace, projectKey, user)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Project User Permission (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Project User Permission (%s): %w", d.Id(), err))
+	}
+
+	log.Printf("[DEBUG] Project User Permission Response Decoded: %#v", permission)
+
+	d.Set("workspace", workspace)
+	d.Set("project_key", projectKey)
+	d.Set("user", user)
+	d.Set("permission", permission.Permission)
+
+	return nil
+}
+
+func resourceProjectUserPermissionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, projectKey, user, err := projectPermissionId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	err = api.ProjectPermissions.DeleteUser(ctx, workspace, projectKey, user)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error revoking Project User Permission (%s): %w", d.Id(), err))
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccBitbucketProjectUserPermission_basic(t *testing.T) {
+	random := testAccCassette(t)
+	resourceName := "bitbucket_project_user_permission.test"
+
+	owner := os.Getenv("BITBUCKET_TEAM")
+	rName := random.name("tf-test")
+	projectKey := random.stringFromCharSet(10, strings.ToUpper(acctest.CharSetAlpha))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketProjectUserPermissionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketProjectUserPermissionConfig(owner, rName, projectKey, "create-repo"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "workspace", "bitbucket_project.test", "owner"),
+					resource.TestCheckResourceAttrPair(resourceName, "project_key", "bitbucket_project.test", "key"),
+					resource.TestCheckResourceAttrPair(resourceName, "user", "data.bitbucket_current_user.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "permission", "create-repo"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccBitbucketProjectUserPermissionConfig(owner, rName, projectKey, "admin"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "permission", "admin"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketProjectUserPermissionDestroy(s *terraform.State) error {
+	api := testAccProvider.Meta().(Clients).api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bitbucket_project_user_permission" {
+			continue
+		}
+
+		workspace, projectKey, user, err := projectPermissionId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = api.ProjectPermissions.GetUser(context.Background(), workspace, projectKey, user)
+		if err == nil {
+			return fmt.Errorf("Project User Permission still exists")
+		}
+		if !IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccBitbucketProjectUserPermissionConfig(owner, rName, projectKey, permission string) string {
+	return fmt.Sprintf(`
+resource "bitbucket_project" "test" {
+  owner = %[1]q
+  name  = %[2]q
+  key   = %[3]q
+}
+
+data "bitbucket_current_user" "test" {}
+
+resource "bitbucket_project_user_permission" "test" {
+  workspace   = bitbucket_project.test.owner
+  project_key = bitbucket_project.test.key
+  user        = data.bitbucket_current_user.test.id
+  permission  = %[4]q
+}
+`, owner, rName, projectKey, permission)
+}
+
+const offlineProjectMember = "{00000000-0000-4000-8000-000000000001}"
+
+func TestBitbucketProjectUserPermission_offline(t *testing.T) {
+	testOfflineLifecycle(t, newFakeBitbucket(t), offlineLifecycle{
+		resource: "bitbucket_project_user_permission",
+		steps: []map[string]interface{}{
+			{"project_key": "PROJ", "user": offlineProjectMember, "permission": "create-repo"},
+			{"project_key": "PROJ", "user": offlineProjectMember, "permission": "admin"},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if state.ID != "fake-workspace/PROJ/"+offlineProjectMember {
+				t.Errorf("unexpected id %s", state.ID)
+			}
+		},
+		importID: func(state *terraform.InstanceState) string { return state.ID },
+	})
+}
+
+func TestBitbucketProjectUserPermission_validation(t *testing.T) {
+	r := resourceProjectUserPermission()
+
+	for user, valid := range map[string]bool{
+		offlineProjectMember:                     true,
+		"{4F3C2E1D-0000-4000-8000-00000000000A}": false,
+		"00000000-0000-4000-8000-000000000001":   false,
+		"{not-a-uuid}":                           false,
+		"557058:00000000-0000-4000-8000-000000":  false,
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"project_key": "PROJ", "user": user, "permission": "read",
+		})
+		if diags := r.Validate(config); diags.HasError() == valid {
+			t.Errorf("user %s: expected valid to be %t, got %v", user, valid, diags)
+		}
+	}
+}
@@ -1,26 +1,35 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/DrFaust92/bitbucket-go-client"
 	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceRepository() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRepositoryCreate,
-		Update: resourceRepositoryUpdate,
-		Read:   resourceRepositoryRead,
-		Delete: resourceRepositoryDelete,
+		CreateContext: resourceRepositoryCreate,
+		UpdateContext: resourceRepositoryUpdate,
+		ReadContext:   resourceRepositoryRead,
+		DeleteContext: resourceRepositoryDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"scm": {
@@ -82,7 +91,8 @@ func resourceRepository() *schema.Resource {
 			},
 			"owner": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"name": {
 				Type:     schema.TypeString,
@@ -156,7 +166,7 @@ func newRepositoryFromResource(d *schema.ResourceData) *bitbucket.Repository {
 	return repo
 }
 
-func resourceRepositoryUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceRepositoryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	repoApi := c.ApiClient.RepositoriesApi
 	pipeApi := c.ApiClient.PipelinesApi
@@ -174,28 +184,27 @@ func resourceRepositoryUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	workspace := d.Get("owner").(string)
-	_, _, err := repoApi.RepositoriesWorkspaceRepoSlugPut(c.AuthContext, repoSlug, workspace, repoBody)
+	_, _, err := repoApi.RepositoriesWorkspaceRepoSlugPut(c.withAuth(ctx), repoSlug, workspace, repoBody)
 
 	if err != nil {
-		return fmt.Errorf("error updating repository (%s): %w", repoSlug, err)
+		return apiDiagnostics(d, fmt.Errorf("error updating repository (%s): %w", repoSlug, err))
 	}
 
 	pipelinesEnabled := d.Get("pipelines_enabled").(bool)
 	pipelinesConfig := &bitbucket.PipelinesConfig{Enabled: pipelinesEnabled}
 
-	_, _, err = pipeApi.UpdateRepositoryPipelineConfig(c.AuthContext, *pipelinesConfig, workspace, repoSlug)
+	_, _, err = pipeApi.UpdateRepositoryPipelineConfig(c.withAuth(ctx), *pipelinesConfig, workspace, repoSlug)
 
 	if err != nil {
-		return fmt.Errorf("error enabling pipeline for repository (%s): %w", repoSlug, err)
+		return apiDiagnostics(d, fmt.Errorf("error enabling pipeline for repository (%s): %w", repoSlug, err))
 	}
 
-	return resourceRepositoryRead(d, m)
+	return resourceRepositoryRead(ctx, d, m)
 }
 
-func resourceRepositoryCreate(d *schema.ResourceData, m interface{}) error {
+func resourceRepositoryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	repoApi := c.ApiClient.RepositoriesApi
-	pipeApi := c.ApiClient.PipelinesApi
 	repo := newRepositoryFromResource(d)
 
 	var repoSlug string
@@ -205,32 +214,70 @@ func resourceRepositoryCreate(d *schema.ResourceData, m interface{}) error {
 	}
 	repoSlug = computeSlug(repoSlug)
 
-	workspace := d.Get("owner").(string)
+	workspace, err := resolveWorkspace(d, m, "owner")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
 
 	repoBody := &bitbucket.RepositoriesApiRepositoriesWorkspaceRepoSlugPostOpts{
 		Body: optional.NewInterface(repo),
 	}
 
-	_, _, err := repoApi.RepositoriesWorkspaceRepoSlugPost(c.AuthContext, repoSlug, workspace, repoBody)
+	_, _, err = repoApi.RepositoriesWorkspaceRepoSlugPost(c.withAuth(ctx), repoSlug, workspace, repoBody)
 	if err != nil {
-		return fmt.Errorf("error creating repository (%s): %w", repoSlug, err)
+		return apiDiagnostics(d, fmt.Errorf("error creating repository (%s): %w", repoSlug, err))
 	}
 
-	d.SetId(string(fmt.Sprintf("%s/%s", d.Get("owner").(string), repoSlug)))
+	d.SetId(string(fmt.Sprintf("%s/%s", workspace, repoSlug)))
 
-	pipelinesEnabled := d.Get("pipelines_enabled").(bool)
-	pipelinesConfig := &bitbucket.PipelinesConfig{Enabled: pipelinesEnabled}
+	err = waitForObject(ctx, d.Timeout(schema.TimeoutCreate), fmt.Sprintf("repository (%s)", d.Id()), func() (bool, error) {
+		_, _, err := repoApi.RepositoriesWorkspaceRepoSlugGet(c.withAuth(ctx), repoSlug, workspace)
+		return err == nil, err
+	})
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	if err := createRepositoryPipelineConfig(ctx, d, m, workspace, repoSlug); err != nil {
+		return apiDiagnostics(d, err)
+	}
 
-	_, _, err = pipeApi.UpdateRepositoryPipelineConfig(c.AuthContext, *pipelinesConfig, workspace, repoSlug)
+	return resourceRepositoryRead(ctx, d, m)
+}
 
+// pipelinesConfigForbiddenTimeout is how long a new repository may refuse its pipelines config with a 403 before the
+// 403 is reported as the permission error it then most likely is
+var pipelinesConfigForbiddenTimeout = 1 * time.Minute
+
+// createRepositoryPipelineConfig enables or disables the pipelines of a new repository. Bitbucket answers with a 403 or
+// 404 until the repository is fully provisioned, forks in particular take a while, so the update is retried until the
+// create timeout passes. A 403 is only retried for pipelinesConfigForbiddenTimeout.
+func createRepositoryPipelineConfig(ctx context.Context, d *schema.ResourceData, m interface{}, workspace, repoSlug string) error {
+	c := m.(Clients).genClient
+	pipeApi := c.ApiClient.PipelinesApi
+	pipelinesConfig := bitbucket.PipelinesConfig{Enabled: d.Get("pipelines_enabled").(bool)}
+
+	var forbiddenSince time.Time
+	err := waitForObject(ctx, d.Timeout(schema.TimeoutCreate), fmt.Sprintf("pipelines of repository (%s)", d.Id()), func() (bool, error) {
+		_, res, err := pipeApi.UpdateRepositoryPipelineConfig(c.withAuth(ctx), pipelinesConfig, workspace, repoSlug)
+		if res != nil && res.StatusCode == http.StatusForbidden {
+			if forbiddenSince.IsZero() {
+				forbiddenSince = time.Now()
+			}
+			if time.Since(forbiddenSince) < pipelinesConfigForbiddenTimeout {
+				return false, nil
+			}
+		}
+		return err == nil, err
+	})
 	if err != nil {
 		return fmt.Errorf("error enabling pipeline for repository (%s): %w", repoSlug, err)
 	}
 
-	return resourceRepositoryRead(d, m)
+	return nil
 }
 
-func resourceRepositoryRead(d *schema.ResourceData, m interface{}) error {
+func resourceRepositoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id := d.Id()
 	if id != "" {
 		idparts := strings.Split(id, "/")
@@ -238,7 +285,7 @@ func resourceRepositoryRead(d *schema.ResourceData, m interface{}) error {
 			d.Set("owner", idparts[0])
 			d.Set("slug", idparts[1])
 		} else {
-			return fmt.Errorf("incorrect ID format, should match `owner/slug`")
+			return diag.Errorf("incorrect ID format, should match `owner/slug`")
 		}
 	}
 
@@ -254,17 +301,17 @@ func resourceRepositoryRead(d *schema.ResourceData, m interface{}) error {
 	repoApi := c.ApiClient.RepositoriesApi
 	pipeApi := c.ApiClient.PipelinesApi
 
-	repoRes, res, err := repoApi.RepositoriesWorkspaceRepoSlugGet(c.AuthContext, repoSlug, workspace)
-	if err != nil {
-		return fmt.Errorf("error reading repository (%s): %w", d.Id(), err)
-	}
-
-	if res.StatusCode == http.StatusNotFound {
+	repoRes, _, err := repoApi.RepositoriesWorkspaceRepoSlugGet(c.withAuth(ctx), repoSlug, workspace)
+	if IsNotFound(err) {
 		log.Printf("[WARN] Repository (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading repository (%s): %w", d.Id(), err))
+	}
+
 	d.Set("scm", repoRes.Scm)
 	d.Set("is_private", repoRes.IsPrivate)
 	d.Set("has_wiki", repoRes.HasWiki)
@@ -288,22 +335,20 @@ func resourceRepositoryRead(d *schema.ResourceData, m interface{}) error {
 
 	d.Set("link", flattenLinks(repoRes.Links))
 
-	pipelinesConfigReq, res, err := pipeApi.GetRepositoryPipelineConfig(c.AuthContext, workspace, repoSlug)
+	pipelinesConfigReq, _, err := pipeApi.GetRepositoryPipelineConfig(c.withAuth(ctx), workspace, repoSlug)
 
-	if err != nil && res.StatusCode != http.StatusNotFound {
-		return err
-	}
-
-	if res.StatusCode == 200 {
-		d.Set("pipelines_enabled", pipelinesConfigReq.Enabled)
-	} else if res.StatusCode == http.StatusNotFound {
+	if IsNotFound(err) {
 		d.Set("pipelines_enabled", false)
+	} else if err != nil {
+		return apiDiagnostics(d, err)
+	} else {
+		d.Set("pipelines_enabled", pipelinesConfigReq.Enabled)
 	}
 
 	return nil
 }
 
-func resourceRepositoryDelete(d *schema.ResourceData, m interface{}) error {
+func resourceRepositoryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var repoSlug string
 	repoSlug = d.Get("slug").(string)
@@ -314,12 +359,12 @@ func resourceRepositoryDelete(d *schema.ResourceData, m interface{}) error {
 	c := m.(Clients).genClient
 	repoApi := c.ApiClient.RepositoriesApi
 
-	res, err := repoApi.RepositoriesWorkspaceRepoSlugDelete(c.AuthContext, repoSlug, d.Get("owner").(string), nil)
+	_, err := repoApi.RepositoriesWorkspaceRepoSlugDelete(c.withAuth(ctx), repoSlug, d.Get("owner").(string), nil)
 	if err != nil {
-		if res.StatusCode == http.StatusNotFound {
+		if IsNotFound(err) {
 			return nil
 		}
-		return fmt.Errorf("error deleting repository (%s): %w", d.Id(), err)
+		return apiDiagnostics(d, fmt.Errorf("error deleting repository (%s): %w", d.Id(), err))
 	}
 
 	return nil
@@ -0,0 +1,302 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// bitbucket_repository_access owns the explicit user and group permissions of a repository. Every grant that isn't
+// configured shows up as a difference when the resource is read and is revoked on the next apply.
+
+func resourceRepositoryAccess() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRepositoryAccessCreate,
+		ReadContext:   resourceRepositoryAccessRead,
+		UpdateContext: resourceRepositoryAccessUpdate,
+		DeleteContext: resourceRepositoryAccessDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uuid": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateUserUUID,
+						},
+						"permission": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(repositoryPermissions, false),
+						},
+					},
+				},
+			},
+			"group": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"slug": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"permission": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(repositoryPermissions, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandRepositoryGrants returns the permissions of the user or group set by the uuid or slug in idField
+func expandRepositoryGrants(set *schema.Set, idField string) (map[string]string, error) {
+	grants := make(map[string]string, set.Len())
+	for _, item := range set.List() {
+		tfMap := item.(map[string]interface{})
+		id := tfMap[idField].(string)
+		if _, ok := grants[id]; ok {
+			return nil, fmt.Errorf("%s is granted access more than once", id)
+		}
+		grants[id] = tfMap["permission"].(string)
+	}
+	return grants, nil
+}
+
+func resourceRepositoryAccessCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", workspace, d.Get("repository").(string)))
+
+	return resourceRepositoryAccessUpdate(ctx, d, m)
+}
+
+func resourceRepositoryAccessRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, repoSlug, err := repositoryAccessId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	users, err := api.RepositoryPermissions.ListUsers(ctx, workspace, repoSlug)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Repository Access (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Repository Access (%s): %w", d.Id(), err))
+	}
+
+	groups, err := api.RepositoryPermissions.ListGroups(ctx, workspace, repoSlug)
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Repository Access (%s): %w", d.Id(), err))
+	}
+
+	userList := make([]interface{}, 0, len(users))
+	for _, permission := range users {
+		if permission.User == nil {
+			continue
+		}
+		userList = append(userList, map[string]interface{}{
+			"uuid":       permission.User.UUID,
+			"permission": permission.Permission,
+		})
+	}
+
+	groupList := make([]interface{}, 0, len(groups))
+	for _, permission := range groups {
+		if permission.Group == nil {
+			continue
+		}
+		groupList = append(groupList, map[string]interface{}{
+			"slug":       permission.Group.Slug,
+			"permission": permission.Permission,
+		})
+	}
+
+	d.Set("workspace", workspace)
+	d.Set("repository", repoSlug)
+	d.Set("user", userList)
+	d.Set("group", groupList)
+
+	return nil
+}
+
+func resourceRepositoryAccessUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, repoSlug, err := repositoryAccessId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	users, err := expandRepositoryGrants(d.Get("user").(*schema.Set), "uuid")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+	groups, err := expandRepositoryGrants(d.Get("group").(*schema.Set), "slug")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	// every principal is looked up before access changes, so a typo doesn't leave the repository half configured
+	for uuid := range users {
+		if _, err := lookupUser(ctx, m, uuid); IsNotFound(err) {
+			return apiDiagnostics(d, fmt.Errorf("user %s not found", uuid))
+		} else if err != nil {
+			return apiDiagnostics(d, err)
+		}
+	}
+	for slug := range groups {
+		if _, err := lookupGroup(ctx, m, workspace, slug); IsNotFound(err) {
+			return apiDiagnostics(d, fmt.Errorf("group %s/%s not found", workspace, slug))
+		} else if err != nil {
+			return apiDiagnostics(d, err)
+		}
+	}
+
+	existingUsers, err := api.RepositoryPermissions.ListUsers(ctx, workspace, repoSlug)
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Repository Access (%s): %w", d.Id(), err))
+	}
+	existingGroups, err := api.RepositoryPermissions.ListGroups(ctx, workspace, repoSlug)
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Repository Access (%s): %w", d.Id(), err))
+	}
+
+	for _, permission := range existingUsers {
+		if permission.User == nil {
+			continue
+		}
+		uuid := permission.User.UUID
+		if want, ok := users[uuid]; ok {
+			if want == permission.Permission {
+				delete(users, uuid)
+			}
+			continue
+		}
+
+		log.Printf("[DEBUG] Revoking access of user %s (%s)", uuid, d.Id())
+		if err := api.RepositoryPermissions.DeleteUser(ctx, workspace, repoSlug, uuid); err != nil && !IsNotFound(err) {
+			return apiDiagnostics(d, fmt.Errorf("error revoking Repository Access of user %s (%s): %w", uuid, d.Id(), err))
+		}
+	}
+
+	for _, permission := range existingGroups {
+		if permission.Group == nil {
+			continue
+		}
+		slug := permission.Group.Slug
+		if want, ok := groups[slug]; ok {
+			if want == permission.Permission {
+				delete(groups, slug)
+			}
+			continue
+		}
+
+		log.Printf("[DEBUG] Revoking access of group %s (%s)", slug, d.Id())
+		if err := api.RepositoryPermissions.DeleteGroup(ctx, workspace, repoSlug, slug); err != nil && !IsNotFound(err) {
+			return apiDiagnostics(d, fmt.Errorf("error revoking Repository Access of group %s (%s): %w", slug, d.Id(), err))
+		}
+	}
+
+	for uuid, permission := range users {
+		log.Printf("[DEBUG] Granting user %s %s access (%s)", uuid, permission, d.Id())
+		if _, err := api.RepositoryPermissions.UpdateUser(ctx, workspace, repoSlug, uuid, permission); err != nil {
+			return apiDiagnostics(d, fmt.Errorf("error granting Repository Access to user %s (%s): %w", uuid, d.Id(), err))
+		}
+	}
+
+	for slug, permission := range groups {
+		log.Printf("[DEBUG] Granting group %s %s access (%s)", slug, permission, d.Id())
+		if _, err := api.RepositoryPermissions.UpdateGroup(ctx, workspace, repoSlug, slug, permission); err != nil {
+			return apiDiagnostics(d, fmt.Errorf("error granting Repository Access to group %s (%s): %w", slug, d.Id(), err))
+		}
+	}
+
+	return resourceRepositoryAccessRead(ctx, d, m)
+}
+
+// resourceRepositoryAccessDelete revokes the configured grants, access granted outside of terraform after the last
+// apply is left alone
+func resourceRepositoryAccessDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, repoSlug, err := repositoryAccessId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	users, err := expandRepositoryGrants(d.Get("user").(*schema.Set), "uuid")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+	groups, err := expandRepositoryGrants(d.Get("group").(*schema.Set), "slug")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	for uuid := range users {
+		if err := api.RepositoryPermissions.DeleteUser(ctx, workspace, repoSlug, uuid); err != nil && !IsNotFound(err) {
+			return apiDiagnostics(d, fmt.Errorf("error revoking Repository Access of user %s (%s): %w", uuid, d.Id(), err))
+		}
+	}
+
+	for slug := range groups {
+		if err := api.RepositoryPermissions.DeleteGroup(ctx, workspace, repoSlug, slug); err != nil && !IsNotFound(err) {
+			return apiDiagnostics(d, fmt.Errorf("error revoking Repository Access of group %s (%s): %w", slug, d.Id(), err))
+		}
+	}
+
+	return nil
+}
+
+func repositoryAccessId(id string) (string, string, error) {
+	parts := strings.Split(id, "/")
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format of ID (%q), expected WORKSPACE-ID/REPO-SLUG", id)
+	}
+
+	return parts[0], parts[1], nil
+}
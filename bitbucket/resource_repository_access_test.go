@@ -0,0 +1,243 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccBitbucketRepositoryAccess_basic(t *testing.T) {
+	random := testAccCassette(t)
+	resourceName := "bitbucket_repository_access.test"
+
+	owner := os.Getenv("BITBUCKET_TEAM")
+	rName := random.name("tf-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketRepositoryAccessConfig(owner, rName, "read"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "workspace", "bitbucket_repository.test", "owner"),
+					resource.TestCheckResourceAttrPair(resourceName, "repository", "bitbucket_repository.test", "name"),
+					resource.TestCheckResourceAttr(resourceName, "user.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "group.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "group.*", map[string]string{
+						"permission": "read",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccBitbucketRepositoryAccessConfig(owner, rName, "write"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "group.*", map[string]string{
+						"permission": "write",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccBitbucketRepositoryAccessConfig(owner, rName, permission string) string {
+	return fmt.Sprintf(`
+resource "bitbucket_repository" "test" {
+  owner = %[1]q
+  name  = %[2]q
+}
+
+resource "bitbucket_group" "test" {
+  workspace = %[1]q
+  name      = %[2]q
+}
+
+data "bitbucket_current_user" "test" {}
+
+resource "bitbucket_repository_access" "test" {
+  workspace  = bitbucket_repository.test.owner
+  repository = bitbucket_repository.test.name
+
+  user {
+    uuid       = data.bitbucket_current_user.test.id
+    permission = "admin"
+  }
+
+  group {
+    slug       = bitbucket_group.test.slug
+    permission = %[3]q
+  }
+}
+`, owner, rName, permission)
+}
+
+const (
+	offlineRepositoryPermissions = "2.0/repositories/fake-workspace/offline-repo/permissions-config"
+	offlineAccessUser            = "{00000000-0000-4000-8000-000000000001}"
+	offlineAccessOther           = "{00000000-0000-4000-8000-000000000002}"
+)
+
+// seedRepositoryAccess seeds the repository, the users and the group the repository access tests grant access to
+func seedRepositoryAccess(f *fakeBitbucket) {
+	f.seedRepository("fake-workspace", "offline-repo")
+	for _, uuid := range []string{offlineAccessUser, offlineAccessOther} {
+		f.seed("2.0/users/"+uuid, map[string]interface{}{"uuid": uuid, "display_name": "Offline User"})
+	}
+	f.seed("1.0/groups/fake-workspace/developers", map[string]interface{}{"name": "Developers", "slug": "developers"})
+}
+
+// fakeGrants returns the number of user and group permissions the fake stores for the offline repository
+func fakeGrants(f *fakeBitbucket) (int, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.children(offlineRepositoryPermissions + "/users")), len(f.children(offlineRepositoryPermissions + "/groups"))
+}
+
+func TestBitbucketRepositoryAccess_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	seedRepositoryAccess(f)
+	// granted by hand before the resource existed
+	f.seed(offlineRepositoryPermissions+"/users/"+offlineAccessOther, map[string]interface{}{
+		"permission": "admin", "user": map[string]interface{}{"uuid": offlineAccessOther},
+	})
+	// the grants are spread over several pages
+	f.pageLen = 1
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_repository_access",
+		steps: []map[string]interface{}{
+			{
+				"repository": "offline-repo",
+				"user": []interface{}{
+					map[string]interface{}{"uuid": offlineAccessUser, "permission": "write"},
+				},
+				"group": []interface{}{
+					map[string]interface{}{"slug": "developers", "permission": "read"},
+				},
+			},
+			{
+				"repository": "offline-repo",
+				"user": []interface{}{
+					map[string]interface{}{"uuid": offlineAccessUser, "permission": "admin"},
+				},
+			},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			expected := [][]int{{1, 1}, {1, 0}}[step]
+			if users, groups := fakeGrants(f); users != expected[0] || groups != expected[1] {
+				t.Errorf("step %d: expected %d user and %d group grants, got %d and %d", step, expected[0], expected[1], users, groups)
+			}
+			if f.exists(offlineRepositoryPermissions + "/users/" + offlineAccessOther) {
+				t.Errorf("step %d: expected the undeclared grant to be revoked", step)
+			}
+		},
+		importID: func(state *terraform.InstanceState) string { return state.ID },
+		// the repository outlives the resource, only its grants are revoked
+		destroyed: func(state *terraform.InstanceState) bool {
+			users, groups := fakeGrants(f)
+			return users == 0 && groups == 0
+		},
+	})
+}
+
+func TestBitbucketRepositoryAccess_offlineDrift(t *testing.T) {
+	f := newFakeBitbucket(t)
+	seedRepositoryAccess(f)
+
+	ctx := context.Background()
+	meta := f.providerMeta(t, nil)
+	r := Provider().ResourcesMap["bitbucket_repository_access"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"repository": "offline-repo",
+		"user": []interface{}{
+			map[string]interface{}{"uuid": offlineAccessUser, "permission": "write"},
+		},
+	})
+
+	diff, err := r.Diff(ctx, nil, config, meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+	state, diags := r.Apply(ctx, nil, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("error applying: %v", diags)
+	}
+
+	// someone grants a group access in the ui
+	f.seed(offlineRepositoryPermissions+"/groups/developers", map[string]interface{}{
+		"permission": "admin", "group": map[string]interface{}{"slug": "developers"},
+	})
+
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, meta)
+	if diags.HasError() {
+		t.Fatalf("error refreshing: %v", diags)
+	}
+	if state.Attributes["group.#"] != "1" {
+		t.Fatalf("expected the undeclared grant to be read, got %#v", state.Attributes)
+	}
+	if _, groups := fakeGrants(f); groups != 1 {
+		t.Fatal("expected reading not to revoke access")
+	}
+
+	diff, err = r.Diff(ctx, state, config, meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+	if diff == nil || diff.Empty() {
+		t.Fatal("expected the undeclared grant to show up in the plan")
+	}
+	if _, diags = r.Apply(ctx, state, diff, meta); diags.HasError() {
+		t.Fatalf("error applying: %v", diags)
+	}
+	if users, groups := fakeGrants(f); users != 1 || groups != 0 {
+		t.Errorf("expected only the declared grant to remain, got %d user and %d group grants", users, groups)
+	}
+}
+
+func TestBitbucketRepositoryAccess_offlineUnknownUser(t *testing.T) {
+	f := newFakeBitbucket(t)
+	seedRepositoryAccess(f)
+
+	diags := testOfflineApplyError(t, f, "bitbucket_repository_access", map[string]interface{}{
+		"repository": "offline-repo",
+		"user": []interface{}{
+			map[string]interface{}{"uuid": offlineAccessUser, "permission": "write"},
+			map[string]interface{}{"uuid": "{00000000-0000-4000-8000-000000000009}", "permission": "read"},
+		},
+	})
+
+	if diags[0].Summary != "user {00000000-0000-4000-8000-000000000009} not found" {
+		t.Errorf("unexpected diagnostics %#v", diags)
+	}
+	if users, _ := fakeGrants(f); users != 0 {
+		t.Errorf("expected no access to be granted, got %d grants", users)
+	}
+}
+
+func TestBitbucketRepositoryAccess_validation(t *testing.T) {
+	r := resourceRepositoryAccess()
+
+	for user, valid := range map[string]bool{
+		offlineAccessUser:                        true,
+		"{4F3C2E1D-0000-4000-8000-00000000000A}": false,
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"repository": "offline-repo",
+			"user":       []interface{}{map[string]interface{}{"uuid": user, "permission": "read"}},
+		})
+		if diags := r.Validate(config); diags.HasError() == valid {
+			t.Errorf("user %s: expected valid to be %t, got %v", user, valid, diags)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Repositories of Bitbucket Data Center live in a project, project_key takes the place of the workspace and is
+// recorded as owner. Wikis, issues, the language and pipelines don't exist on Data Center and are left as
+// configured.
+
+func newDataCenterRepositoryFromResource(d *schema.ResourceData) *DataCenterRepository {
+	return &DataCenterRepository{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		ScmID:       d.Get("scm").(string),
+		Forkable:    d.Get("fork_policy").(string) != "no_forks",
+		Public:      !d.Get("is_private").(bool),
+	}
+}
+
+func resourceRepositoryDataCenterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	projectKey := d.Get("project_key").(string)
+	if projectKey == "" {
+		return diag.Errorf("project_key is required on Bitbucket Data Center")
+	}
+
+	repo, err := client.CreateRepository(ctx, projectKey, newDataCenterRepositoryFromResource(d))
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error creating repository (%s): %w", d.Get("name").(string), err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", projectKey, repo.Slug))
+
+	return resourceRepositoryDataCenterRead(ctx, d, m)
+}
+
+func resourceRepositoryDataCenterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return diag.Errorf("incorrect ID format, should match `project_key/slug`")
+	}
+
+	repo, err := client.GetRepository(ctx, idparts[0], idparts[1])
+	if IsNotFound(err) {
+		log.Printf("[WARN] Repository (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading repository (%s): %w", d.Id(), err))
+	}
+
+	d.Set("owner", idparts[0])
+	d.Set("project_key", idparts[0])
+	d.Set("name", repo.Name)
+	d.Set("slug", repo.Slug)
+	d.Set("description", repo.Description)
+	d.Set("scm", repo.ScmID)
+	d.Set("is_private", !repo.Public)
+	d.Set("uuid", strconv.Itoa(repo.ID))
+	d.Set("link", []interface{}{})
+
+	if !repo.Forkable {
+		d.Set("fork_policy", "no_forks")
+	} else if policy := d.Get("fork_policy").(string); policy != "allow_forks" && policy != "no_public_forks" {
+		d.Set("fork_policy", "allow_forks")
+	}
+
+	if repo.Links != nil {
+		for _, cloneURL := range repo.Links.Clone {
+			if cloneURL.Name == "ssh" {
+				d.Set("clone_ssh", cloneURL.Href)
+			} else {
+				d.Set("clone_https", cloneURL.Href)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceRepositoryDataCenterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return diag.Errorf("incorrect ID format, should match `project_key/slug`")
+	}
+
+	repository := newDataCenterRepositoryFromResource(d)
+	if d.HasChange("project_key") {
+		// moves the repository to the new project
+		repository.Project = &DataCenterProject{Key: d.Get("project_key").(string)}
+	}
+
+	repo, err := client.UpdateRepository(ctx, idparts[0], idparts[1], repository)
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error updating repository (%s): %w", d.Id(), err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("project_key").(string), repo.Slug))
+
+	return resourceRepositoryDataCenterRead(ctx, d, m)
+}
+
+func resourceRepositoryDataCenterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(Clients).dataCenter
+
+	idparts := strings.Split(d.Id(), "/")
+	if len(idparts) != 2 {
+		return diag.Errorf("incorrect ID format, should match `project_key/slug`")
+	}
+
+	err := client.DeleteRepository(ctx, idparts[0], idparts[1])
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting repository (%s): %w", d.Id(), err))
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceRepositoryGroupPermission() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRepositoryGroupPermissionPut,
+		ReadContext:   resourceRepositoryGroupPermissionRead,
+		UpdateContext: resourceRepositoryGroupPermissionPut,
+		DeleteContext: resourceRepositoryGroupPermissionDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(repositoryPermissions, false),
+			},
+		},
+	}
+}
+
+func resourceRepositoryGroupPermissionPut(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+	repoSlug := d.Get("repository").(string)
+	groupSlug := d.Get("group").(string)
+
+	_, err = api.RepositoryPermissions.UpdateGroup(ctx, workspace, repoSlug, groupSlug, d.Get("permission").(string))
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error granting Repository Group Permission (%s/%s/%s): %w", workspace, repoSlug, groupSlug, err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", workspace, repoSlug, groupSlug))
+
+	return resourceRepositoryGroupPermissionRead(ctx, d, m)
+}
+
+func resourceRepositoryGroupPermissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, repoSlug, groupSlug, err := permissionId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	permission, err := api.RepositoryPermissions.GetGroup(ctx, workspace, repoSlug, groupSlug)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Repository Group Permission (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Repository Group Permission (%s): %w", d.Id(), err))
+	}
+
+	log.Printf("[DEBUG] Repository Group Permission Response Decoded: %#v", permission)
+
+	d.Set("workspace", workspace)
+	d.Set("repository", repoSlug)
+	d.Set("group", groupSlug)
+	d.Set("permission", permission.Permission)
+
+	return nil
+}
+
+func resourceRepositoryGroupPermissionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, repoSlug, groupSlug, err := permissionId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	err = api.RepositoryPermissions.DeleteGroup(ctx, workspace, repoSlug, groupSlug)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error revoking Repository Group Permission (%s): %w", d.Id(), err))
+	}
+
+	return nil
+}
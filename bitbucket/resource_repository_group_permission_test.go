@@ -0,0 +1,106 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccBitbucketRepositoryGroupPermission_basic(t *testing.T) {
+	random := testAccCassette(t)
+	resourceName := "bitbucket_repository_group_permission.test"
+
+	owner := os.Getenv("BITBUCKET_TEAM")
+	rName := random.name("tf-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketRepositoryGroupPermissionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketRepositoryGroupPermissionConfig(owner, rName, "read"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "workspace", "bitbucket_repository.test", "owner"),
+					resource.TestCheckResourceAttrPair(resourceName, "repository", "bitbucket_repository.test", "name"),
+					resource.TestCheckResourceAttrPair(resourceName, "group", "bitbucket_group.test", "slug"),
+					resource.TestCheckResourceAttr(resourceName, "permission", "read"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccBitbucketRepositoryGroupPermissionConfig(owner, rName, "admin"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "permission", "admin"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketRepositoryGroupPermissionDestroy(s *terraform.State) error {
+	api := testAccProvider.Meta().(Clients).api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bitbucket_repository_group_permission" {
+			continue
+		}
+
+		workspace, repoSlug, groupSlug, err := permissionId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = api.RepositoryPermissions.GetGroup(context.Background(), workspace, repoSlug, groupSlug)
+		if err == nil {
+			return fmt.Errorf("Repository Group Permission still exists")
+		}
+		if !IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccBitbucketRepositoryGroupPermissionConfig(owner, rName, permission string) string {
+	return fmt.Sprintf(`
+resource "bitbucket_repository" "test" {
+  owner = %[1]q
+  name  = %[2]q
+}
+
+resource "bitbucket_group" "test" {
+  workspace = %[1]q
+  name      = %[2]q
+}
+
+resource "bitbucket_repository_group_permission" "test" {
+  workspace  = bitbucket_repository.test.owner
+  repository = bitbucket_repository.test.name
+  group      = bitbucket_group.test.slug
+  permission = %[3]q
+}
+`, owner, rName, permission)
+}
+
+func TestBitbucketRepositoryGroupPermission_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_repository_group_permission",
+		steps: []map[string]interface{}{
+			{"repository": "offline-repo", "group": "developers", "permission": "write"},
+			{"repository": "offline-repo", "group": "developers", "permission": "read"},
+		},
+		importID: func(state *terraform.InstanceState) string { return "fake-workspace/offline-repo/developers" },
+	})
+}
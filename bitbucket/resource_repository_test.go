@@ -4,15 +4,16 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccBitbucketRepository_basic(t *testing.T) {
-	rName := acctest.RandomWithPrefix("tf-test")
+	rName := testAccCassette(t).name("tf-test")
 	testUser := os.Getenv("BITBUCKET_TEAM")
 	resourceName := "bitbucket_repository.test"
 
@@ -52,7 +53,8 @@ func TestAccBitbucketRepository_basic(t *testing.T) {
 }
 
 func TestAccBitbucketRepository_project(t *testing.T) {
-	rName := acctest.RandomWithPrefix("tf-test")
+	random := testAccCassette(t)
+	rName := random.name("tf-test")
 	testUser := os.Getenv("BITBUCKET_TEAM")
 	resourceName := "bitbucket_repository.test"
 
@@ -80,7 +82,8 @@ func TestAccBitbucketRepository_project(t *testing.T) {
 }
 
 func TestAccBitbucketRepository_avatar(t *testing.T) {
-	rName := acctest.RandomWithPrefix("tf-test")
+	random := testAccCassette(t)
+	rName := random.name("tf-test")
 	testUser := os.Getenv("BITBUCKET_TEAM")
 	resourceName := "bitbucket_repository.test"
 
@@ -191,3 +194,73 @@ func testAccCheckBitbucketRepositoryExists(n string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func TestBitbucketRepository_offline(t *testing.T) {
+	testOfflineLifecycle(t, newFakeBitbucket(t), offlineLifecycle{
+		resource: "bitbucket_repository",
+		steps: []map[string]interface{}{
+			{"name": "offline-repo", "pipelines_enabled": true},
+			{"name": "offline-repo", "description": "updated", "has_wiki": true, "is_private": false},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if state.Attributes["uuid"] == "" || state.Attributes["clone_https"] == "" || state.Attributes["project_key"] == "" {
+				t.Errorf("expected the computed attributes to be set, got %#v", state.Attributes)
+			}
+		},
+		importID: func(state *terraform.InstanceState) string { return state.ID },
+	})
+}
+
+func TestBitbucketRepository_offlineEventuallyConsistent(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.lag = 2
+	f.provisioning = 2
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_repository",
+		steps: []map[string]interface{}{
+			{"name": "offline-repo", "pipelines_enabled": true},
+		},
+	})
+}
+
+func TestBitbucketRepository_offlinePipelinesForbidden(t *testing.T) {
+	f := newFakeBitbucket(t)
+	// the pipelines config is refused for good, e.g. without admin rights on the repository
+	f.provisioning = 1000
+
+	timeout := pipelinesConfigForbiddenTimeout
+	pipelinesConfigForbiddenTimeout = 500 * time.Millisecond
+	t.Cleanup(func() { pipelinesConfigForbiddenTimeout = timeout })
+
+	diags := testOfflineApplyError(t, f, "bitbucket_repository", map[string]interface{}{"name": "offline-repo", "pipelines_enabled": true})
+
+	if !strings.Contains(diags[0].Summary, "403") {
+		t.Errorf("expected the 403 to be reported, got %#v", diags)
+	}
+}
+
+func TestBitbucketRepository_offlineDataCenter(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedDataCenterRepository("PROJ", "existing")
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_repository",
+		provider: testDataCenterProvider,
+		steps: []map[string]interface{}{
+			{"name": "offline-repo", "project_key": "PROJ"},
+			{"name": "offline-repo", "project_key": "PROJ", "description": "updated", "fork_policy": "no_forks", "is_private": false},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if state.ID != "PROJ/offline-repo" || state.Attributes["owner"] != "PROJ" {
+				t.Errorf("expected the repository to belong to PROJ, got %#v", state.Attributes)
+			}
+			if state.Attributes["clone_https"] == "" || state.Attributes["clone_ssh"] == "" {
+				t.Errorf("expected the clone urls to be set, got %#v", state.Attributes)
+			}
+		},
+		importID: func(state *terraform.InstanceState) string { return state.ID },
+		// wikis, issues and pipelines don't exist on Data Center
+		importIgnore: []string{"has_wiki", "has_issues", "pipelines_enabled"},
+	})
+}
@@ -0,0 +1,120 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceRepositoryUserPermission() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRepositoryUserPermissionPut,
+		ReadContext:   resourceRepositoryUserPermissionRead,
+		UpdateContext: resourceRepositoryUserPermissionPut,
+		DeleteContext: resourceRepositoryUserPermissionDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateUserUUID,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(repositoryPermissions, false),
+			},
+		},
+	}
+}
+
+func resourceRepositoryUserPermissionPut(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+	repoSlug := d.Get("repository").(string)
+	user := d.Get("user").(string)
+
+	_, err = api.RepositoryPermissions.UpdateUser(ctx, workspace, repoSlug, user, d.Get("permission").(string))
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error granting Repository User Permission (%s/%s/%s): %w", workspace, repoSlug, user, err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", workspace, repoSlug, user))
+
+	return resourceRepositoryUserPermissionRead(ctx, d, m)
+}
+
+func resourceRepositoryUserPermissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, repoSlug, user, err := permissionId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	permission, err := api.RepositoryPermissions.GetUser(ctx, workspace, repoSlug, user)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Repository User Permission (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Repository User Permission (%s): %w", d.Id(), err))
+	}
+
+	log.Printf("[DEBUG] Repository User Permission Response Decoded: %#v", permission)
+
+	d.Set("workspace", workspace)
+	d.Set("repository", repoSlug)
+	d.Set("user", user)
+	d.Set("permission", permission.Permission)
+
+	return nil
+}
+
+func resourceRepositoryUserPermissionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, repoSlug, user, err := permissionId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	err = api.RepositoryPermissions.DeleteUser(ctx, workspace, repoSlug, user)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error revoking Repository User Permission (%s): %w", d.Id(), err))
+	}
+
+	return nil
+}
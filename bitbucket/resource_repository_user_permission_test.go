@@ -0,0 +1,163 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccBitbucketRepositoryUserPermission_basic(t *testing.T) {
+	random := testAccCassette(t)
+	resourceName := "bitbucket_repository_user_permission.test"
+
+	owner := os.Getenv("BITBUCKET_TEAM")
+	rName := random.name("tf-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketRepositoryUserPermissionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketRepositoryUserPermissionConfig(owner, rName, "read"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "workspace", "bitbucket_repository.test", "owner"),
+					resource.TestCheckResourceAttrPair(resourceName, "repository", "bitbucket_repository.test", "name"),
+					resource.TestCheckResourceAttrPair(resourceName, "user", "data.bitbucket_current_user.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "permission", "read"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccBitbucketRepositoryUserPermissionConfig(owner, rName, "write"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "permission", "write"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketRepositoryUserPermissionDestroy(s *terraform.State) error {
+	api := testAccProvider.Meta().(Clients).api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bitbucket_repository_user_permission" {
+			continue
+		}
+
+		workspace, repoSlug, user, err := permissionId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = api.RepositoryPermissions.GetUser(context.Background(), workspace, repoSlug, user)
+		if err == nil {
+			return fmt.Errorf("Repository User Permission still exists")
+		}
+		if !IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccBitbucketRepositoryUserPermissionConfig(owner, rName, permission string) string {
+	return fmt.Sprintf(`
+resource "bitbucket_repository" "test" {
+  owner = %[1]q
+  name  = %[2]q
+}
+
+data "bitbucket_current_user" "test" {}
+
+resource "bitbucket_repository_user_permission" "test" {
+  workspace  = bitbucket_repository.test.owner
+  repository = bitbucket_repository.test.name
+  user       = data.bitbucket_current_user.test.id
+  permission = %[3]q
+}
+`, owner, rName, permission)
+}
+
+const offlineRepositoryMember = "{00000000-0000-4000-8000-000000000002}"
+
+func TestBitbucketRepositoryUserPermission_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_repository_user_permission",
+		steps: []map[string]interface{}{
+			{"repository": "offline-repo", "user": offlineRepositoryMember, "permission": "read"},
+			{"repository": "offline-repo", "user": offlineRepositoryMember, "permission": "admin"},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if state.ID != "fake-workspace/offline-repo/"+offlineRepositoryMember {
+				t.Errorf("unexpected id %s", state.ID)
+			}
+		},
+		importID: func(state *terraform.InstanceState) string { return state.ID },
+	})
+}
+
+func TestBitbucketRepositoryUserPermission_offlineDrift(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+	path := "2.0/repositories/fake-workspace/offline-repo/permissions-config/users/" + offlineRepositoryMember
+	f.seed(path, map[string]interface{}{"permission": "admin"})
+
+	meta := f.providerMeta(t, nil)
+	d := schema.TestResourceDataRaw(t, resourceRepositoryUserPermission().Schema, map[string]interface{}{
+		"repository": "offline-repo", "user": offlineRepositoryMember, "permission": "read",
+	})
+	d.SetId("fake-workspace/offline-repo/" + offlineRepositoryMember)
+
+	// access granted in the ui shows up as a change of the permission
+	if diags := resourceRepositoryUserPermissionRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Get("permission") != "admin" {
+		t.Errorf("expected the permission to be admin, got %v", d.Get("permission"))
+	}
+
+	// access revoked in the ui removes the resource so it is granted again
+	f.mu.Lock()
+	delete(f.objects, path)
+	f.mu.Unlock()
+
+	if diags := resourceRepositoryUserPermissionRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the revoked permission to be removed from state, got %s", d.Id())
+	}
+}
+
+func TestBitbucketRepositoryUserPermission_validation(t *testing.T) {
+	r := resourceRepositoryUserPermission()
+
+	for user, valid := range map[string]bool{
+		offlineRepositoryMember:                  true,
+		"{4F3C2E1D-0000-4000-8000-00000000000A}": false,
+		"00000000-0000-4000-8000-000000000002":   false,
+		"557058:00000000-0000-4000-8000-000000":  false,
+		"":                                       false,
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"repository": "offline-repo", "user": user, "permission": "read",
+		})
+		if diags := r.Validate(config); diags.HasError() == valid {
+			t.Errorf("user %s: expected valid to be %t, got %v", user, valid, diags)
+		}
+	}
+}
@@ -1,21 +1,29 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"strings"
+	"time"
 
 	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceRepositoryVariable() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRepositoryVariableCreate,
-		Update: resourceRepositoryVariableUpdate,
-		Read:   resourceRepositoryVariableRead,
-		Delete: resourceRepositoryVariableDelete,
+		CreateContext: resourceRepositoryVariableCreate,
+		UpdateContext: resourceRepositoryVariableUpdate,
+		ReadContext:   resourceRepositoryVariableRead,
+		DeleteContext: resourceRepositoryVariableDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"uuid": {
@@ -53,7 +61,7 @@ func newRepositoryVariableFromResource(d *schema.ResourceData) bitbucket.Pipelin
 	return dk
 }
 
-func resourceRepositoryVariableCreate(d *schema.ResourceData, m interface{}) error {
+func resourceRepositoryVariableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 	rvcr := newRepositoryVariableFromResource(d)
@@ -61,41 +69,42 @@ func resourceRepositoryVariableCreate(d *schema.ResourceData, m interface{}) err
 	repo := d.Get("repository").(string)
 	workspace, repoSlug, err := repoVarId(repo)
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	rvRes, _, err := pipeApi.CreateRepositoryPipelineVariable(c.AuthContext, rvcr, workspace, repoSlug)
+	rvRes, _, err := pipeApi.CreateRepositoryPipelineVariable(c.withAuth(ctx), rvcr, workspace, repoSlug)
 
 	if err != nil {
-		return fmt.Errorf("error creating Repository Variable (%s): %w", repo, err)
+		return apiDiagnostics(d, fmt.Errorf("error creating Repository Variable (%s): %w", repo, err))
 	}
 
 	d.Set("uuid", rvRes.Uuid)
 	d.SetId(rvRes.Key)
 
-	return resourceRepositoryVariableRead(d, m)
+	return resourceRepositoryVariableRead(ctx, d, m)
 }
 
-func resourceRepositoryVariableRead(d *schema.ResourceData, m interface{}) error {
+func resourceRepositoryVariableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
 	repo := d.Get("repository").(string)
 	workspace, repoSlug, err := repoVarId(repo)
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	rvRes, res, err := pipeApi.GetRepositoryPipelineVariable(c.AuthContext, workspace, repoSlug, d.Get("uuid").(string))
-	if err != nil {
-		return fmt.Errorf("error reading Repository Variable (%s): %w", d.Id(), err)
-	}
-	if res.StatusCode == http.StatusNotFound {
+	rvRes, _, err := pipeApi.GetRepositoryPipelineVariable(c.withAuth(ctx), workspace, repoSlug, d.Get("uuid").(string))
+	if IsNotFound(err) {
 		log.Printf("[WARN] Repository Variable (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Repository Variable (%s): %w", d.Id(), err))
+	}
+
 	d.Set("uuid", rvRes.Uuid)
 	d.Set("key", rvRes.Key)
 	d.Set("secured", rvRes.Secured)
@@ -109,39 +118,39 @@ func resourceRepositoryVariableRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceRepositoryVariableUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceRepositoryVariableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
 	repo := d.Get("repository").(string)
 	workspace, repoSlug, err := repoVarId(repo)
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
 	rvcr := newRepositoryVariableFromResource(d)
 
-	_, _, err = pipeApi.UpdateRepositoryPipelineVariable(c.AuthContext, rvcr, workspace, repoSlug, d.Get("uuid").(string))
+	_, _, err = pipeApi.UpdateRepositoryPipelineVariable(c.withAuth(ctx), rvcr, workspace, repoSlug, d.Get("uuid").(string))
 	if err != nil {
-		return fmt.Errorf("error updating Repository Variable (%s): %w", d.Id(), err)
+		return apiDiagnostics(d, fmt.Errorf("error updating Repository Variable (%s): %w", d.Id(), err))
 	}
 
-	return resourceRepositoryVariableRead(d, m)
+	return resourceRepositoryVariableRead(ctx, d, m)
 }
 
-func resourceRepositoryVariableDelete(d *schema.ResourceData, m interface{}) error {
+func resourceRepositoryVariableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	pipeApi := c.ApiClient.PipelinesApi
 
 	repo := d.Get("repository").(string)
 	workspace, repoSlug, err := repoVarId(repo)
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	_, err = pipeApi.DeleteRepositoryPipelineVariable(c.AuthContext, workspace, repoSlug, d.Get("uuid").(string))
-	if err != nil {
-		return fmt.Errorf("error deleting Repository Variable (%s): %w", d.Id(), err)
+	_, err = pipeApi.DeleteRepositoryPipelineVariable(c.withAuth(ctx), workspace, repoSlug, d.Get("uuid").(string))
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Repository Variable (%s): %w", d.Id(), err))
 	}
 
 	return nil
@@ -6,15 +6,16 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccBitbucketRepositoryVariable_basic(t *testing.T) {
+	random := testAccCassette(t)
 
 	owner := os.Getenv("BITBUCKET_TEAM")
-	rName := acctest.RandomWithPrefix("tf-test")
+	rName := random.name("tf-test")
 	resourceName := "bitbucket_repository_variable.test"
 
 	resource.Test(t, resource.TestCase{
@@ -99,3 +100,29 @@ resource "bitbucket_repository_variable" "test" {
 }
 `, team, rName, val)
 }
+
+func TestBitbucketRepositoryVariable_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_repository_variable",
+		steps: []map[string]interface{}{
+			{"repository": "fake-workspace/offline-repo", "key": "offline", "value": "plain"},
+			{"repository": "fake-workspace/offline-repo", "key": "offline", "value": "hidden", "secured": true},
+		},
+	})
+}
+
+func TestBitbucketRepositoryVariable_offlineInvalidKey(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+
+	diags := testOfflineApplyError(t, f, "bitbucket_repository_variable", map[string]interface{}{
+		"repository": "fake-workspace/offline-repo", "key": "not valid", "value": "plain",
+	})
+
+	if len(diags) != 2 || diags[1].Summary != "key: must be alphanumeric" || !diags[1].AttributePath.Equals(cty.GetAttrPath("key")) {
+		t.Errorf("expected the error to be attached to key, got %#v", diags)
+	}
+}
@@ -0,0 +1,136 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// bitbucket_repository_variables manages many pipeline variables of a repository at once. Variables are matched by
+// their key, with exclusive set the variables that aren't configured are deleted.
+
+func resourceRepositoryVariables() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRepositoryVariablesCreate,
+		UpdateContext: resourceRepositoryVariablesUpdate,
+		ReadContext:   resourceRepositoryVariablesRead,
+		DeleteContext: resourceRepositoryVariablesDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRepositoryVariablesImport,
+		},
+
+		Schema: variablesSchema("repository"),
+	}
+}
+
+// repositoryVariableClient manages the pipeline variables of the repository with the id workspace/repo-slug
+func repositoryVariableClient(m interface{}, id string) (variableClient, error) {
+	c := m.(Clients).genClient
+	pipeApi := c.ApiClient.PipelinesApi
+	api := m.(Clients).api
+
+	workspace, repoSlug, err := repoVarId(id)
+	if err != nil {
+		return variableClient{}, err
+	}
+
+	return variableClient{
+		name: "Repository Variable",
+		list: func(ctx context.Context) ([]bitbucket.PipelineVariable, error) {
+			return api.RepositoryVariables.List(ctx, workspace, repoSlug)
+		},
+		create: func(ctx context.Context, variable bitbucket.PipelineVariable) error {
+			_, _, err := pipeApi.CreateRepositoryPipelineVariable(c.withAuth(ctx), variable, workspace, repoSlug)
+			return err
+		},
+		update: func(ctx context.Context, variable bitbucket.PipelineVariable) error {
+			_, _, err := pipeApi.UpdateRepositoryPipelineVariable(c.withAuth(ctx), variable, workspace, repoSlug, variable.Uuid)
+			return err
+		},
+		delete: func(ctx context.Context, uuid string) error {
+			_, err := pipeApi.DeleteRepositoryPipelineVariable(c.withAuth(ctx), workspace, repoSlug, uuid)
+			return err
+		},
+	}, nil
+}
+
+func resourceRepositoryVariablesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	repo := d.Get("repository").(string)
+	if _, _, err := repoVarId(repo); err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	d.SetId(repo)
+
+	return resourceRepositoryVariablesUpdate(ctx, d, m)
+}
+
+func resourceRepositoryVariablesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := repositoryVariableClient(m, d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	err = readVariables(ctx, d, client)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Repository Variables (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Repository Variables (%s): %w", d.Id(), err))
+	}
+
+	d.Set("repository", d.Id())
+
+	return nil
+}
+
+func resourceRepositoryVariablesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := repositoryVariableClient(m, d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	if err := reconcileVariables(ctx, d, client); err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	return resourceRepositoryVariablesRead(ctx, d, m)
+}
+
+func resourceRepositoryVariablesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := repositoryVariableClient(m, d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	return apiDiagnostics(d, deleteVariables(ctx, d, client))
+}
+
+func resourceRepositoryVariablesImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client, err := repositoryVariableClient(m, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := importVariables(ctx, d, client); err != nil {
+		return nil, err
+	}
+
+	d.Set("repository", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,174 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccBitbucketRepositoryVariables_basic(t *testing.T) {
+	random := testAccCassette(t)
+	owner := os.Getenv("BITBUCKET_TEAM")
+	rName := random.name("tf-test")
+	resourceName := "bitbucket_repository_variables.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketRepositoryVariablesConfig(owner, rName, "test-val"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "repository", "bitbucket_repository.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "variable.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "variable.*", map[string]string{
+						"key":     "plain",
+						"value":   "test-val",
+						"secured": "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "variable.*", map[string]string{
+						"key":     "hidden",
+						"secured": "true",
+					}),
+				),
+			},
+			{
+				Config: testAccBitbucketRepositoryVariablesConfig(owner, rName, "test-val-2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "variable.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "variable.*", map[string]string{
+						"key":   "plain",
+						"value": "test-val-2",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccBitbucketRepositoryVariablesConfig(team, rName, val string) string {
+	return fmt.Sprintf(`
+resource "bitbucket_repository" "test" {
+  owner = %[1]q
+  name  = %[2]q
+}
+
+resource "bitbucket_repository_variables" "test" {
+  repository = bitbucket_repository.test.id
+  exclusive  = true
+
+  variable {
+    key   = "plain"
+    value = %[3]q
+  }
+
+  variable {
+    key     = "hidden"
+    value   = "secret"
+    secured = true
+  }
+}
+`, team, rName, val)
+}
+
+// fakeVariableKeys returns the sorted keys of the variables the fake stores at path
+func fakeVariableKeys(f *fakeBitbucket, path string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var keys []string
+	for _, v := range f.children(path) {
+		keys = append(keys, v.(map[string]interface{})["key"].(string))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+const offlineRepositoryVariables = "2.0/repositories/fake-workspace/offline-repo/pipelines_config/variables"
+
+func TestBitbucketRepositoryVariables_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+	f.seed(offlineRepositoryVariables+"/{manual}", map[string]interface{}{
+		"uuid": "{manual}", "key": "MANUAL", "value": "by hand", "secured": false,
+	})
+	// the variables are spread over several pages
+	f.pageLen = 2
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_repository_variables",
+		steps: []map[string]interface{}{
+			{
+				"repository": "fake-workspace/offline-repo",
+				"variable": []interface{}{
+					map[string]interface{}{"key": "PLAIN", "value": "plain"},
+					map[string]interface{}{"key": "HIDDEN", "value": "hidden", "secured": true},
+				},
+			},
+			{
+				"repository": "fake-workspace/offline-repo",
+				"variable": []interface{}{
+					map[string]interface{}{"key": "PLAIN", "value": "updated"},
+					map[string]interface{}{"key": "HIDDEN", "value": "rotated", "secured": true},
+					map[string]interface{}{"key": "ADDED", "value": "added"},
+				},
+			},
+			{
+				"repository": "fake-workspace/offline-repo",
+				"variable": []interface{}{
+					map[string]interface{}{"key": "PLAIN", "value": "updated"},
+					map[string]interface{}{"key": "HIDDEN", "value": "unhidden"},
+				},
+			},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			expected := [][]string{
+				{"HIDDEN", "MANUAL", "PLAIN"},
+				{"ADDED", "HIDDEN", "MANUAL", "PLAIN"},
+				{"HIDDEN", "MANUAL", "PLAIN"},
+			}[step]
+			if keys := fakeVariableKeys(f, offlineRepositoryVariables); fmt.Sprint(keys) != fmt.Sprint(expected) {
+				t.Errorf("step %d: expected the variables %v, got %v", step, expected, keys)
+			}
+		},
+		// the repository outlives the resource, only the managed variables are deleted
+		destroyed: func(state *terraform.InstanceState) bool {
+			return fmt.Sprint(fakeVariableKeys(f, offlineRepositoryVariables)) == "[MANUAL]"
+		},
+	})
+}
+
+func TestBitbucketRepositoryVariables_offlineExclusive(t *testing.T) {
+	f := newFakeBitbucket(t)
+	f.seedRepository("fake-workspace", "offline-repo")
+	f.seed(offlineRepositoryVariables+"/{manual}", map[string]interface{}{
+		"uuid": "{manual}", "key": "MANUAL", "value": "by hand", "secured": false,
+	})
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_repository_variables",
+		steps: []map[string]interface{}{
+			{
+				"repository": "fake-workspace/offline-repo",
+				"exclusive":  true,
+				"variable": []interface{}{
+					map[string]interface{}{"key": "PLAIN", "value": "plain"},
+				},
+			},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if keys := fakeVariableKeys(f, offlineRepositoryVariables); fmt.Sprint(keys) != "[PLAIN]" {
+				t.Errorf("expected the unmanaged variable to be deleted, got %v", keys)
+			}
+		},
+		importID:     func(state *terraform.InstanceState) string { return state.ID },
+		importIgnore: []string{"exclusive"},
+		destroyed: func(state *terraform.InstanceState) bool {
+			return len(fakeVariableKeys(f, offlineRepositoryVariables)) == 0
+		},
+	})
+}
@@ -1,33 +1,32 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"strings"
+	"time"
 
 	"github.com/DrFaust92/bitbucket-go-client"
 	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-// sshKey is the data we need to send to create a new SSH Key for the repository
-type SshKey struct {
-	ID      int    `json:"id,omitempty"`
-	UUID    string `json:"uuid,omitempty"`
-	Key     string `json:"key,omitempty"`
-	Label   string `json:"label,omitempty"`
-	Comment string `json:"comment,omitempty"`
-}
-
 func resourceSshKey() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSshKeysCreate,
-		Read:   resourceSshKeysRead,
-		Update: resourceSshKeysUpdate,
-		Delete: resourceSshKeysDelete,
+		CreateContext: resourceSshKeysCreate,
+		ReadContext:   resourceSshKeysRead,
+		UpdateContext: resourceSshKeysUpdate,
+		DeleteContext: resourceSshKeysDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -57,7 +56,7 @@ func resourceSshKey() *schema.Resource {
 	}
 }
 
-func resourceSshKeysCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSshKeysCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	sshApi := c.ApiClient.SshApi
 
@@ -68,38 +67,38 @@ func resourceSshKeysCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	user := d.Get("user").(string)
-	sshKeyReq, _, err := sshApi.UsersSelectedUserSshKeysPost(c.AuthContext, user, sshKeyBody)
+	sshKeyReq, _, err := sshApi.UsersSelectedUserSshKeysPost(c.withAuth(ctx), user, sshKeyBody)
 	if err != nil {
-		return fmt.Errorf("error creating ssh key: %w", err)
+		return apiDiagnostics(d, fmt.Errorf("error creating ssh key: %w", err))
 	}
 
 	d.SetId(string(fmt.Sprintf("%s/%s", user, sshKeyReq.Uuid)))
 
-	return resourceSshKeysRead(d, m)
+	return resourceSshKeysRead(ctx, d, m)
 }
 
-func resourceSshKeysRead(d *schema.ResourceData, m interface{}) error {
+func resourceSshKeysRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	sshApi := c.ApiClient.SshApi
 
 	user, keyId, err := sshKeyId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	sshKeyReq, res, err := sshApi.UsersSelectedUserSshKeysKeyIdGet(c.AuthContext, keyId, user)
-	if err != nil {
-		return fmt.Errorf("error reading ssh key (%s): %w", d.Id(), err)
-	}
-
-	if res.StatusCode == http.StatusNotFound {
+	sshKeyReq, res, err := sshApi.UsersSelectedUserSshKeysKeyIdGet(c.withAuth(ctx), keyId, user)
+	if IsNotFound(err) {
 		log.Printf("[WARN] SSH Key (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading ssh key (%s): %w", d.Id(), err))
+	}
+
 	if res.Body == nil {
-		return fmt.Errorf("error getting SSH Key (%s): empty response", d.Id())
+		return diag.Errorf("error getting SSH Key (%s): empty response", d.Id())
 	}
 
 	d.Set("user", user)
@@ -111,7 +110,7 @@ func resourceSshKeysRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSshKeysUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSshKeysUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	sshApi := c.ApiClient.SshApi
 
@@ -123,32 +122,32 @@ func resourceSshKeysUpdate(d *schema.ResourceData, m interface{}) error {
 
 	user, keyId, err := sshKeyId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	_, _, err = sshApi.UsersSelectedUserSshKeysKeyIdPut(c.AuthContext, keyId, user, sshKeyBody)
+	_, _, err = sshApi.UsersSelectedUserSshKeysKeyIdPut(c.withAuth(ctx), keyId, user, sshKeyBody)
 	if err != nil {
-		return fmt.Errorf("error updating ssh key (%s): %w", d.Id(), err)
+		return apiDiagnostics(d, fmt.Errorf("error updating ssh key (%s): %w", d.Id(), err))
 	}
 
-	return resourceSshKeysRead(d, m)
+	return resourceSshKeysRead(ctx, d, m)
 }
 
-func resourceSshKeysDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSshKeysDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(Clients).genClient
 	sshApi := c.ApiClient.SshApi
 
 	user, keyId, err := sshKeyId(d.Id())
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	res, err := sshApi.UsersSelectedUserSshKeysKeyIdDelete(c.AuthContext, keyId, user)
+	_, err = sshApi.UsersSelectedUserSshKeysKeyIdDelete(c.withAuth(ctx), keyId, user)
 	if err != nil {
-		if res.StatusCode == http.StatusNotFound {
+		if IsNotFound(err) {
 			return nil
 		}
-		return fmt.Errorf("error deleting ssh key (%s): %w", d.Id(), err)
+		return apiDiagnostics(d, fmt.Errorf("error deleting ssh key (%s): %w", d.Id(), err))
 	}
 
 	return nil
@@ -6,17 +6,18 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
 func TestAccBitbucketSshKey_basic(t *testing.T) {
-	var sshKey SshKey
+	random := testAccCassette(t)
+	var sshKey bitbucketapi.SshKey
 	resourceName := "bitbucket_ssh_key.test"
 
 	userEmail := os.Getenv("BITBUCKET_USERNAME")
-	publicKey, _, err := RandSSHKeyPairSize(2048, userEmail)
+	publicKey, _, err := random.sshKeyPair(2048, userEmail)
 	if err != nil {
 		t.Fatalf("error generating random SSH key: %s", err)
 	}
@@ -47,13 +48,14 @@ func TestAccBitbucketSshKey_basic(t *testing.T) {
 }
 
 func TestAccBitbucketSshKey_label(t *testing.T) {
-	var sshKey SshKey
+	random := testAccCassette(t)
+	var sshKey bitbucketapi.SshKey
 	resourceName := "bitbucket_ssh_key.test"
 
-	rName := acctest.RandomWithPrefix("tf-test")
-	rName2 := acctest.RandomWithPrefix("tf-test")
+	rName := random.name("tf-test")
+	rName2 := random.name("tf-test")
 	userEmail := os.Getenv("BITBUCKET_USERNAME")
-	publicKey, _, err := RandSSHKeyPairSize(2048, userEmail)
+	publicKey, _, err := random.sshKeyPair(2048, userEmail)
 	if err != nil {
 		t.Fatalf("error generating random SSH key: %s", err)
 	}
@@ -114,7 +116,7 @@ func testAccCheckBitbucketSshKeyDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckBitbucketSshKeyExists(n string, sshKey *SshKey) resource.TestCheckFunc {
+func testAccCheckBitbucketSshKeyExists(n string, sshKey *bitbucketapi.SshKey) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
@@ -149,3 +151,28 @@ resource "bitbucket_ssh_key" "test" {
 }
 `, pubkey, label)
 }
+
+func TestBitbucketSshKey_offline(t *testing.T) {
+	publicKey, _, err := RandSSHKeyPairSize(2048, "offline@example.com")
+	if err != nil {
+		t.Fatalf("error generating random SSH key: %s", err)
+	}
+
+	testOfflineLifecycle(t, newFakeBitbucket(t), offlineLifecycle{
+		resource: "bitbucket_ssh_key",
+		steps: []map[string]interface{}{
+			{"user": "fake-user", "key": publicKey, "label": "offline"},
+			{"user": "fake-user", "key": publicKey, "label": "updated"},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if state.ID != "fake-user/"+state.Attributes["uuid"] {
+				t.Errorf("expected the key to be identified by its uuid, got %q", state.ID)
+			}
+			if expected := []string{"offline", "updated"}[step]; state.Attributes["label"] != expected {
+				t.Errorf("step %d: expected the label %q, got %#v", step, expected, state.Attributes)
+			}
+		},
+		importID:     func(state *terraform.InstanceState) string { return state.ID },
+		importIgnore: []string{"key"},
+	})
+}
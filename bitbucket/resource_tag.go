@@ -0,0 +1,188 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
+)
+
+func resourceTag() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTagCreate,
+		ReadContext:   resourceTagRead,
+		UpdateContext: resourceTagUpdate,
+		DeleteContext: resourceTagDelete,
+		CustomizeDiff: resourceTagCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			// a target that resolves to another commit replaces the tag, see resourceTagCustomizeDiff
+			"target": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"message": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceTagCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+	repoSlug := d.Get("repository").(string)
+	name := d.Get("name").(string)
+	target := d.Get("target").(string)
+
+	// tags are created on a commit, a target branch is resolved to the commit it points at
+	commit, err := api.Commits.Get(ctx, workspace, repoSlug, target)
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error resolving target (%s) of Tag (%s): %w", target, name, err))
+	}
+
+	tag, err := api.Tags.Create(ctx, workspace, repoSlug, &bitbucketapi.Tag{
+		Name:    name,
+		Target:  &bitbucketapi.Commit{Hash: commit.Hash},
+		Message: d.Get("message").(string),
+	})
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error creating Tag (%s): %w", name, err))
+	}
+
+	log.Printf("[DEBUG] Tag Create Response Decoded: %#v", tag)
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", workspace, repoSlug, name))
+
+	return resourceTagRead(ctx, d, m)
+}
+
+func resourceTagRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, repoSlug, name, err := refId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	tag, err := api.Tags.Get(ctx, workspace, repoSlug, name)
+	if IsNotFound(err) {
+		log.Printf("[WARN] Tag (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Tag (%s): %w", d.Id(), err))
+	}
+
+	d.Set("workspace", workspace)
+	d.Set("repository", repoSlug)
+	d.Set("name", tag.Name)
+	// bitbucket stores the message like git does, ending in a newline the configuration doesn't have
+	d.Set("message", strings.TrimRight(tag.Message, "\n"))
+	if tag.Target != nil {
+		d.Set("hash", tag.Target.Hash)
+	}
+
+	// the target is only known to the configuration, an imported tag targets its commit
+	if _, ok := d.GetOk("target"); !ok {
+		d.Set("target", d.Get("hash"))
+	}
+
+	return nil
+}
+
+// resourceTagUpdate records a target that still resolves to the commit of the tag, the tag itself is left alone
+func resourceTagUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceTagRead(ctx, d, m)
+}
+
+func resourceTagDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
+
+	workspace, repoSlug, name, err := refId(d.Id())
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	err = api.Tags.Delete(ctx, workspace, repoSlug, name)
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Tag (%s): %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+// resourceTagCustomizeDiff replaces a tag when its new target resolves to another commit. A target that still
+// resolves to the commit of the tag, e.g. the branch an imported tag was cut from, is only recorded.
+func resourceTagCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("target") {
+		return nil
+	}
+
+	if !d.NewValueKnown("target") {
+		return d.ForceNew("target")
+	}
+
+	workspace, repoSlug, _, err := refId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// a target that can't be resolved is reported when the tag is created again
+	commit, err := m.(Clients).api.Commits.Get(ctx, workspace, repoSlug, d.Get("target").(string))
+	if err != nil {
+		log.Printf("[DEBUG] Resolving target of Tag (%s): %s", d.Id(), err)
+		return d.ForceNew("target")
+	}
+
+	if commit.Hash != d.Get("hash").(string) {
+		return d.ForceNew("target")
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccBitbucketTag_basic(t *testing.T) {
+	random := testAccCassette(t)
+	resourceName := "bitbucket_tag.test"
+
+	workspace := os.Getenv("BITBUCKET_TEAM")
+	// a repository created by the test has no commits to tag, so the bootstrapped repo is used
+	repo := os.Getenv("BITBUCKET_PIPELINED_REPO")
+	name := random.name("tf-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketTagDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketTagConfig(workspace, repo, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "workspace", workspace),
+					resource.TestCheckResourceAttr(resourceName, "repository", repo),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "target", "master"),
+					resource.TestCheckResourceAttr(resourceName, "message", "release "+name),
+					resource.TestCheckResourceAttrSet(resourceName, "hash"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"target"},
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketTagDestroy(s *terraform.State) error {
+	api := testAccProvider.Meta().(Clients).api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bitbucket_tag" {
+			continue
+		}
+
+		workspace, repoSlug, name, err := refId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = api.Tags.Get(context.Background(), workspace, repoSlug, name)
+		if err == nil {
+			return fmt.Errorf("Tag still exists")
+		}
+		if !IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccBitbucketTagConfig(workspace, repo, name string) string {
+	return fmt.Sprintf(`
+resource "bitbucket_tag" "test" {
+  workspace  = %[1]q
+  repository = %[2]q
+  name       = %[3]q
+  target     = "master"
+  message    = "release %[3]s"
+}
+`, workspace, repo, name)
+}
+
+func TestBitbucketTag_offline(t *testing.T) {
+	f := newFakeBitbucket(t)
+	seedBranches(f)
+
+	testOfflineLifecycle(t, f, offlineLifecycle{
+		resource: "bitbucket_tag",
+		steps: []map[string]interface{}{
+			{"repository": "offline-repo", "name": "release/v1.0.0", "target": "main", "message": "release v1.0.0"},
+			// the commit main points at, the tag stays where it is
+			{"repository": "offline-repo", "name": "release/v1.0.0", "target": offlineCommit, "message": "release v1.0.0"},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if state.ID != "fake-workspace/offline-repo/release/v1.0.0" || state.Attributes["hash"] != offlineCommit {
+				t.Errorf("unexpected tag %s %#v", state.ID, state.Attributes)
+			}
+		},
+		importID:     func(state *terraform.InstanceState) string { return state.ID },
+		importIgnore: []string{"target"},
+	})
+}
+
+func TestBitbucketTag_offlineMove(t *testing.T) {
+	f := newFakeBitbucket(t)
+	seedBranches(f)
+	const otherCommit = "89abcdef0123456789abcdef0123456789abcdef"
+	f.seed(offlineRepository+"/commit/"+otherCommit, map[string]interface{}{"hash": otherCommit})
+
+	ctx := context.Background()
+	meta := f.providerMeta(t, nil)
+	r := Provider().ResourcesMap["bitbucket_tag"]
+	config := func(target string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"repository": "offline-repo", "name": "v1.0.0", "target": target,
+		})
+	}
+
+	diff, err := r.Diff(ctx, nil, config(offlineCommit), meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+	state, diags := r.Apply(ctx, nil, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("error applying: %v", diags)
+	}
+
+	// main resolves to the commit of the tag, e.g. after an import
+	diff, err = r.Diff(ctx, state, config("main"), meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+	if diff == nil || diff.RequiresNew() {
+		t.Errorf("expected a target resolving to the same commit not to replace the tag, got %#v", diff)
+	}
+
+	diff, err = r.Diff(ctx, state, config(otherCommit), meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Fatalf("expected moving the tag to replace it, got %#v", diff)
+	}
+
+	// terraform destroys the old tag before it creates the new one
+	if _, diags = r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, meta); diags.HasError() {
+		t.Fatalf("error destroying: %v", diags)
+	}
+	diff, err = r.Diff(ctx, nil, config(otherCommit), meta)
+	if err != nil {
+		t.Fatalf("error planning: %s", err)
+	}
+	if state, diags = r.Apply(ctx, nil, diff, meta); diags.HasError() {
+		t.Fatalf("error applying: %v", diags)
+	}
+	if state.Attributes["hash"] != otherCommit {
+		t.Errorf("expected the tag to point at %s, got %s", otherCommit, state.Attributes["hash"])
+	}
+}
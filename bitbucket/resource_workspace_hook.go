@@ -1,27 +1,31 @@
 package bitbucket
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"net/http"
-	"net/url"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWorkspaceHook() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWorkspaceHookCreate,
-		Read:   resourceWorkspaceHookRead,
-		Update: resourceWorkspaceHookUpdate,
-		Delete: resourceWorkspaceHookDelete,
+		CreateContext: resourceWorkspaceHookCreate,
+		ReadContext:   resourceWorkspaceHookRead,
+		UpdateContext: resourceWorkspaceHookUpdate,
+		DeleteContext: resourceWorkspaceHookDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idParts := strings.Split(d.Id(), "/")
 				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected workspace/REPO/HOOK-ID", d.Id())
@@ -35,7 +39,8 @@ func resourceWorkspaceHook() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"workspace": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"active": {
@@ -98,108 +103,66 @@ func resourceWorkspaceHook() *schema.Resource {
 	}
 }
 
-func resourceWorkspaceHookCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
-	hook := createHook(d)
+func resourceWorkspaceHookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
-	payload, err := json.Marshal(hook)
+	workspace, err := resolveWorkspace(d, m, "workspace")
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	hookReq, err := client.Post(fmt.Sprintf("2.0/workspaces/%s/hooks",
-		d.Get("workspace").(string),
-	), bytes.NewBuffer(payload))
-
+	hook, err := api.WorkspaceHooks.Create(ctx, workspace, createHook(d))
 	if err != nil {
-		return err
-	}
-
-	body, readerr := ioutil.ReadAll(hookReq.Body)
-	if readerr != nil {
-		return readerr
-	}
-
-	decodeerr := json.Unmarshal(body, &hook)
-	if decodeerr != nil {
-		return decodeerr
+		return apiDiagnostics(d, err)
 	}
 
 	d.SetId(hook.UUID)
 
-	return resourceWorkspaceHookRead(d, m)
+	return resourceWorkspaceHookRead(ctx, d, m)
 }
-func resourceWorkspaceHookRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
+func resourceWorkspaceHookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
-	hookReq, err := client.Get(fmt.Sprintf("2.0/workspaces/%s/hooks/%s",
-		d.Get("workspace").(string),
-		url.PathEscape(d.Id()),
-	))
+	hook, err := api.WorkspaceHooks.Get(ctx, d.Get("workspace").(string), d.Id())
 
-	if hookReq.StatusCode == http.StatusNotFound {
-		log.Printf("[WARN] Repository Hook (%s) not found, removing from state", d.Id())
+	if IsNotFound(err) {
+		log.Printf("[WARN] Workspace Hook (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
 	if err != nil {
-		return err
+		return apiDiagnostics(d, fmt.Errorf("error reading Workspace Hook (%s): %w", d.Id(), err))
 	}
 
-	log.Printf("ID: %s", url.PathEscape(d.Id()))
-
-	if hookReq.StatusCode == 200 {
-		var hook Hook
-
-		body, readerr := ioutil.ReadAll(hookReq.Body)
-		if readerr != nil {
-			return readerr
-		}
-
-		decodeerr := json.Unmarshal(body, &hook)
-		if decodeerr != nil {
-			return decodeerr
-		}
-
-		d.Set("uuid", hook.UUID)
-		d.Set("description", hook.Description)
-		d.Set("active", hook.Active)
-		d.Set("url", hook.URL)
-		d.Set("skip_cert_verification", hook.SkipCertVerification)
-		d.Set("events", hook.Events)
-	}
+	d.Set("uuid", hook.UUID)
+	d.Set("description", hook.Description)
+	d.Set("active", hook.Active)
+	d.Set("url", hook.URL)
+	d.Set("skip_cert_verification", hook.SkipCertVerification)
+	d.Set("events", hook.Events)
 
 	return nil
 }
 
-func resourceWorkspaceHookUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
-	hook := createHook(d)
-	payload, err := json.Marshal(hook)
-	if err != nil {
-		return err
-	}
-
-	_, err = client.Put(fmt.Sprintf("2.0/workspaces/%s/hooks/%s",
-		d.Get("workspace").(string),
-		url.PathEscape(d.Id()),
-	), bytes.NewBuffer(payload))
+func resourceWorkspaceHookUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
+	_, err := api.WorkspaceHooks.Update(ctx, d.Get("workspace").(string), d.Id(), createHook(d))
 	if err != nil {
-		return err
+		return apiDiagnostics(d, err)
 	}
 
-	return resourceWorkspaceHookRead(d, m)
+	return resourceWorkspaceHookRead(ctx, d, m)
 }
 
-func resourceWorkspaceHookDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(Clients).httpClient
-	_, err := client.Delete(fmt.Sprintf("2.0/workspaces/%s/hooks/%s",
-		d.Get("workspace").(string),
-		url.PathEscape(d.Id()),
-	))
+func resourceWorkspaceHookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(Clients).api
 
-	return err
+	err := api.WorkspaceHooks.Delete(ctx, d.Get("workspace").(string), d.Id())
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Workspace Hook (%s): %w", d.Id(), err))
+	}
 
+	return nil
 }
@@ -1,22 +1,24 @@
 package bitbucket
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
 )
 
 func TestAccBitbucketWorkspaceHook_basic(t *testing.T) {
-	var hook Hook
+	random := testAccCassette(t)
+	var hook bitbucketapi.Hook
 	resourceName := "bitbucket_workspace_hook.test"
 	workspace := os.Getenv("BITBUCKET_TEAM")
-	rName := acctest.RandomWithPrefix("tf-test")
+	rName := random.name("tf-test")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -76,7 +78,7 @@ func testAccCheckBitbucketWorkspaceHookDestroy(s *terraform.State) error {
 			continue
 		}
 
-		response, err := client.Get(fmt.Sprintf("2.0/workspaces/%s/hooks/%s", rs.Primary.Attributes["workspace"], url.PathEscape(rs.Primary.Attributes["uuid"])))
+		response, err := client.Get(context.Background(), fmt.Sprintf("2.0/workspaces/%s/hooks/%s", rs.Primary.Attributes["workspace"], url.PathEscape(rs.Primary.Attributes["uuid"])))
 
 		if err == nil {
 			return fmt.Errorf("The resource was found should have errored")
@@ -90,7 +92,7 @@ func testAccCheckBitbucketWorkspaceHookDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckBitbucketWorkspaceHookExists(n string, hook *Hook) resource.TestCheckFunc {
+func testAccCheckBitbucketWorkspaceHookExists(n string, hook *bitbucketapi.Hook) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
@@ -143,3 +145,16 @@ func testAccBitbucketWorkspaceHookImportStateIdFunc(resourceName string) resourc
 		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["workspace"], rs.Primary.ID), nil
 	}
 }
+
+func TestBitbucketWorkspaceHook_offline(t *testing.T) {
+	testOfflineLifecycle(t, newFakeBitbucket(t), offlineLifecycle{
+		resource: "bitbucket_workspace_hook",
+		steps: []map[string]interface{}{
+			{"url": "https://example.com/hook", "description": "offline", "events": []interface{}{"repo:push"}},
+			{"url": "https://example.com/hook", "description": "updated", "events": []interface{}{"repo:push"}, "skip_cert_verification": false},
+		},
+		importID: func(state *terraform.InstanceState) string {
+			return fmt.Sprintf("fake-workspace/%s", state.ID)
+		},
+	})
+}
@@ -0,0 +1,178 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceWorkspaceVariable() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWorkspaceVariableCreate,
+		UpdateContext: resourceWorkspaceVariableUpdate,
+		ReadContext:   resourceWorkspaceVariableRead,
+		DeleteContext: resourceWorkspaceVariableDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceWorkspaceVariableImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"value": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"secured": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func newWorkspaceVariableFromResource(d *schema.ResourceData) bitbucket.PipelineVariable {
+	return bitbucket.PipelineVariable{
+		Key:     d.Get("key").(string),
+		Value:   d.Get("value").(string),
+		Secured: d.Get("secured").(bool),
+	}
+}
+
+func resourceWorkspaceVariableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(Clients).genClient
+	pipeApi := c.ApiClient.PipelinesApi
+
+	workspace, err := resolveWorkspace(d, m, "workspace")
+	if err != nil {
+		return apiDiagnostics(d, err)
+	}
+
+	opts := &bitbucket.PipelinesApiCreatePipelineVariableForWorkspaceOpts{
+		Body: optional.NewInterface(newWorkspaceVariableFromResource(d)),
+	}
+	variable, _, err := pipeApi.CreatePipelineVariableForWorkspace(c.withAuth(ctx), workspace, opts)
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error creating Workspace Variable (%s): %w", workspace, err))
+	}
+
+	d.SetId(variable.Uuid)
+
+	return resourceWorkspaceVariableRead(ctx, d, m)
+}
+
+func resourceWorkspaceVariableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(Clients).genClient
+	pipeApi := c.ApiClient.PipelinesApi
+
+	variable, _, err := pipeApi.GetPipelineVariableForWorkspace(c.withAuth(ctx), d.Get("workspace").(string), d.Id())
+	if IsNotFound(err) {
+		log.Printf("[WARN] Workspace Variable (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error reading Workspace Variable (%s): %w", d.Id(), err))
+	}
+
+	d.Set("uuid", variable.Uuid)
+	d.Set("key", variable.Key)
+	d.Set("secured", variable.Secured)
+
+	if !variable.Secured {
+		d.Set("value", variable.Value)
+	} else {
+		d.Set("value", d.Get("value").(string))
+	}
+
+	return nil
+}
+
+func resourceWorkspaceVariableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(Clients).genClient
+	pipeApi := c.ApiClient.PipelinesApi
+
+	variable := newWorkspaceVariableFromResource(d)
+	variable.Uuid = d.Id()
+
+	_, _, err := pipeApi.UpdatePipelineVariableForWorkspace(c.withAuth(ctx), variable, d.Get("workspace").(string), d.Id())
+	if err != nil {
+		return apiDiagnostics(d, fmt.Errorf("error updating Workspace Variable (%s): %w", d.Id(), err))
+	}
+
+	return resourceWorkspaceVariableRead(ctx, d, m)
+}
+
+func resourceWorkspaceVariableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(Clients).genClient
+	pipeApi := c.ApiClient.PipelinesApi
+
+	_, err := pipeApi.DeletePipelineVariableForWorkspace(c.withAuth(ctx), d.Get("workspace").(string), d.Id())
+	if err != nil && !IsNotFound(err) {
+		return apiDiagnostics(d, fmt.Errorf("error deleting Workspace Variable (%s): %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+// resourceWorkspaceVariableImport imports workspace/VARIABLE-UUID or workspace/KEY, the value of a secured variable
+// is unknown until it is configured
+func resourceWorkspaceVariableImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.SplitN(d.Id(), "/", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%q), expected workspace/VARIABLE-UUID or workspace/KEY", d.Id())
+	}
+
+	workspace, id := idParts[0], idParts[1]
+	if !strings.HasPrefix(id, "{") {
+		variables, err := m.(Clients).api.WorkspaceVariables.List(ctx, workspace)
+		if err != nil {
+			return nil, fmt.Errorf("error reading Workspace Variables (%s): %w", workspace, err)
+		}
+
+		var uuid string
+		for _, variable := range variables {
+			if variable.Key == id {
+				uuid = variable.Uuid
+				break
+			}
+		}
+		if uuid == "" {
+			return nil, fmt.Errorf("workspace %s has no variable %s", workspace, id)
+		}
+		id = uuid
+	}
+
+	d.SetId(id)
+	d.Set("workspace", workspace)
+
+	return []*schema.ResourceData{d}, nil
+}
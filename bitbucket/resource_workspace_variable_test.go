@@ -0,0 +1,110 @@
+package bitbucket
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccBitbucketWorkspaceVariable_basic(t *testing.T) {
+	random := testAccCassette(t)
+	workspace := os.Getenv("BITBUCKET_TEAM")
+	rName := random.stringFromCharSet(10, acctest.CharSetAlpha)
+	resourceName := "bitbucket_workspace_variable.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBitbucketWorkspaceVariableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBitbucketWorkspaceVariableConfig(workspace, rName, "test-val"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "workspace", workspace),
+					resource.TestCheckResourceAttr(resourceName, "key", "TF_TEST_"+rName),
+					resource.TestCheckResourceAttr(resourceName, "value", "test-val"),
+					resource.TestCheckResourceAttr(resourceName, "secured", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "uuid"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccBitbucketWorkspaceHookImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccBitbucketWorkspaceVariableConfig(workspace, rName, "test-val-2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "key", "TF_TEST_"+rName),
+					resource.TestCheckResourceAttr(resourceName, "value", "test-val-2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBitbucketWorkspaceVariableDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(Clients).genClient
+	pipeApi := client.ApiClient.PipelinesApi
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bitbucket_workspace_variable" {
+			continue
+		}
+
+		_, _, err := pipeApi.GetPipelineVariableForWorkspace(client.AuthContext, rs.Primary.Attributes["workspace"], rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Workspace Variable still exists")
+		}
+		if !IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccBitbucketWorkspaceVariableConfig(workspace, rName, val string) string {
+	return fmt.Sprintf(`
+resource "bitbucket_workspace_variable" "test" {
+  workspace = %[1]q
+  key       = "TF_TEST_%[2]s"
+  value     = %[3]q
+}
+`, workspace, rName, val)
+}
+
+func TestBitbucketWorkspaceVariable_offline(t *testing.T) {
+	testOfflineLifecycle(t, newFakeBitbucket(t), offlineLifecycle{
+		resource: "bitbucket_workspace_variable",
+		steps: []map[string]interface{}{
+			{"key": "OFFLINE", "value": "plain"},
+			{"key": "OFFLINE", "value": "hidden", "secured": true},
+		},
+		check: func(t *testing.T, step int, state *terraform.InstanceState) {
+			if state.Attributes["workspace"] != "fake-workspace" || state.Attributes["uuid"] != state.ID {
+				t.Errorf("unexpected variable %#v", state.Attributes)
+			}
+		},
+		importID: func(state *terraform.InstanceState) string {
+			return fmt.Sprintf("fake-workspace/%s", state.ID)
+		},
+		importIgnore: []string{"value"},
+	})
+}
+
+func TestBitbucketWorkspaceVariable_offlineImportByKey(t *testing.T) {
+	testOfflineLifecycle(t, newFakeBitbucket(t), offlineLifecycle{
+		resource: "bitbucket_workspace_variable",
+		steps: []map[string]interface{}{
+			{"workspace": "fake-workspace", "key": "OFFLINE", "value": "plain"},
+		},
+		importID: func(state *terraform.InstanceState) string {
+			return "fake-workspace/OFFLINE"
+		},
+	})
+}
@@ -0,0 +1,183 @@
+package bitbucket
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a failed request is retried when max_retries is not set
+	DefaultMaxRetries int = 5
+	// DefaultRetryMaxWait is the longest time in seconds to wait between two attempts when retry_max_wait is not set
+	DefaultRetryMaxWait int = 30
+
+	retryMinWait = 1 * time.Second
+)
+
+// retryTransport retries requests that were rate limited or failed on the server side. It is shared by the
+// internal Client and the generated api client so both back off the same way.
+type retryTransport struct {
+	transport  http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func newRetryTransport(transport http.RoundTripper, maxRetries int, maxWait time.Duration) *retryTransport {
+	return &retryTransport{
+		transport:  transport,
+		maxRetries: maxRetries,
+		minWait:    retryMinWait,
+		maxWait:    maxWait,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		// Track whether the request made it onto the wire, a request that was never written can
+		// always be sent again regardless of its verb.
+		wroteRequest := false
+		trace := &httptrace.ClientTrace{
+			WroteHeaders: func() { wroteRequest = true },
+		}
+		attemptReq = attemptReq.WithContext(httptrace.WithClientTrace(attemptReq.Context(), trace))
+
+		resp, err := t.transport.RoundTrip(attemptReq)
+
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err, wroteRequest) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if err != nil {
+			log.Printf("[DEBUG] %s %s failed (%s), retrying in %s", req.Method, req.URL.Path, err, wait)
+		} else {
+			log.Printf("[DEBUG] %s %s returned %d, retrying in %s", req.Method, req.URL.Path, resp.StatusCode, wait)
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rewindRequest returns a request that can be sent for the given attempt, the body of the original request
+// has already been consumed after the first attempt so it is recreated from GetBody.
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	if req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	newReq := req.Clone(req.Context())
+	newReq.Body = body
+	return newReq, nil
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error, wroteRequest bool) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	// a body that can not be replayed can not be retried
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return !wroteRequest || isIdempotent(req.Method)
+	}
+
+	// rate limited requests are rejected before bitbucket processes them
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusNotImplemented {
+		return isIdempotent(req.Method)
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff honours the Retry-After header when present and otherwise applies exponential backoff with full jitter.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > t.maxWait {
+				return t.maxWait
+			}
+			return wait
+		}
+	}
+
+	wait := float64(t.minWait) * math.Pow(2, float64(attempt))
+	if wait > float64(t.maxWait) {
+		wait = float64(t.maxWait)
+	}
+
+	return time.Duration(rand.Int63n(int64(wait) + 1))
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,114 @@
+package bitbucket
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// newBaseTransport returns the transport every outbound request of the provider ends up on, including the generated
+// api client, the oauth token endpoint and the ip ranges. It honours the proxy and tls arguments of the provider
+// configuration and otherwise behaves like http.DefaultTransport, so HTTPS_PROXY and friends keep working.
+func newBaseTransport(d *schema.ResourceData) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if v, ok := d.GetOk("proxy_url"); ok && v.(string) != "" {
+		proxyURL, err := url.Parse(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing proxy_url: %w", err)
+		}
+		log.Printf("[DEBUG] Using proxy %s", proxyURL.Redacted())
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := newTLSConfig(d)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport, nil
+}
+
+// newTLSConfig builds the tls configuration from the ca_cert_*, client_* and insecure_skip_verify arguments. It is
+// nil when none of them is set.
+func newTLSConfig(d *schema.ResourceData) (*tls.Config, error) {
+	caPEM := d.Get("ca_cert_pem").(string)
+	if path := d.Get("ca_cert_file").(string); path != "" {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading ca_cert_file (%s): %w", path, err)
+		}
+		caPEM = string(b)
+	}
+	clientCert := d.Get("client_cert").(string)
+	clientKey := d.Get("client_key").(string)
+	insecure := d.Get("insecure_skip_verify").(bool)
+
+	if caPEM == "" && clientCert == "" && !insecure {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if caPEM != "" {
+		// the bundle is added to the system roots, so a corporate ca doesn't cut off every other server
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(caPEM)) {
+			return nil, fmt.Errorf("error parsing the ca certificates: no PEM encoded certificate found")
+		}
+		config.RootCAs = pool
+	}
+
+	if clientCert != "" {
+		certPEM, err := pemOrFile("client_cert", clientCert)
+		if err != nil {
+			return nil, err
+		}
+		keyPEM, err := pemOrFile("client_key", clientKey)
+		if err != nil {
+			return nil, err
+		}
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		log.Printf("[DEBUG] Using TLS client certificate")
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if insecure {
+		log.Printf("[WARN] TLS certificate verification is disabled, insecure_skip_verify is set")
+		config.InsecureSkipVerify = true
+	}
+
+	return config, nil
+}
+
+// pemOrFile returns value when it is PEM encoded and otherwise reads the file at the path it names
+func pemOrFile(attribute, value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	b, err := ioutil.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s (%s): %w", attribute, value, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,196 @@
+package bitbucket
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func configureTestProvider(config map[string]interface{}) (interface{}, diag.Diagnostics) {
+	config["username"] = "fake-user"
+	config["password"] = "fake-password"
+	config["max_retries"] = 0
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+	return p.Meta(), diags
+}
+
+// serverCAPEM returns the certificate of the tls test server PEM encoded, it is its own ca
+func serverCAPEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func TestProviderTransport_caCertPEM(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	meta, diags := configureTestProvider(map[string]interface{}{"api_url": server.URL})
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+	client := meta.(Clients).httpClient
+	if _, err := client.Get(context.Background(), "2.0/user"); err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Fatalf("expected the unknown ca to be rejected, got %v", err)
+	}
+
+	meta, diags = configureTestProvider(map[string]interface{}{
+		"api_url":     server.URL,
+		"ca_cert_pem": serverCAPEM(server),
+	})
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+	client = meta.(Clients).httpClient
+	if _, err := client.Get(context.Background(), "2.0/user"); err != nil {
+		t.Fatalf("expected the configured ca to be trusted, got %s", err)
+	}
+}
+
+func TestProviderTransport_invalidCACert(t *testing.T) {
+	_, diags := configureTestProvider(map[string]interface{}{"ca_cert_pem": "not a certificate"})
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "no PEM encoded certificate found") {
+		t.Fatalf("expected an invalid ca to be rejected, got %v", diags)
+	}
+}
+
+func TestProviderTransport_insecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	meta, diags := configureTestProvider(map[string]interface{}{
+		"api_url":              server.URL,
+		"insecure_skip_verify": true,
+	})
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+	client := meta.(Clients).httpClient
+	if _, err := client.Get(context.Background(), "2.0/user"); err != nil {
+		t.Fatalf("expected certificate verification to be skipped, got %s", err)
+	}
+}
+
+func TestProviderTransport_clientCertificate(t *testing.T) {
+	var subject string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject = r.TLS.PeerCertificates[0].Subject.CommonName
+		fmt.Fprint(w, `{}`)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	certPEM, keyPEM := testClientCertificate(t, "terraform")
+
+	meta, diags := configureTestProvider(map[string]interface{}{
+		"api_url":     server.URL,
+		"ca_cert_pem": serverCAPEM(server),
+		"client_cert": certPEM,
+		"client_key":  keyPEM,
+	})
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+
+	c := meta.(Clients).genClient
+	if _, _, err := c.ApiClient.WorkspacesApi.WorkspacesWorkspaceGet(c.withAuth(context.Background()), "ws"); err != nil {
+		t.Fatalf("expected the generated api client to present the client certificate, got %s", err)
+	}
+	if subject != "terraform" {
+		t.Errorf("expected the client certificate to be presented, got %q", subject)
+	}
+}
+
+func TestProviderTransport_proxy(t *testing.T) {
+	var hosts []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts = append(hosts, r.URL.Host)
+		if r.URL.Host == "ip-ranges.test" {
+			if r.Header.Get("Authorization") != "" {
+				t.Errorf("expected the ip ranges to be fetched without credentials")
+			}
+			fmt.Fprint(w, `{"syncToken":42,"items":[{"network":"10.0.0.0","mask_len":8,"cidr":"10.0.0.0/8","mask":"255.0.0.0"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer proxy.Close()
+
+	defer func(url string) { ipRangesURL = url }(ipRangesURL)
+	ipRangesURL = "http://ip-ranges.test/"
+
+	meta, diags := configureTestProvider(map[string]interface{}{
+		"api_url":   "http://bitbucket.test/",
+		"proxy_url": proxy.URL,
+	})
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+
+	client := meta.(Clients).httpClient
+	if _, err := client.Get(context.Background(), "2.0/user"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, dataIPRanges().Schema, map[string]interface{}{})
+	if diags := dataReadIPRanges(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "42" || d.Get("ranges.#").(int) != 1 {
+		t.Errorf("expected the ranges served through the proxy, got id %q and %d ranges", d.Id(), d.Get("ranges.#").(int))
+	}
+
+	if len(hosts) != 2 || hosts[0] != "bitbucket.test" || hosts[1] != "ip-ranges.test" {
+		t.Errorf("expected both requests to go through the proxy, got %v", hosts)
+	}
+}
+
+// testClientCertificate returns a self signed certificate and its key, both PEM encoded
+func testClientCertificate(t *testing.T, commonName string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
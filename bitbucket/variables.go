@@ -0,0 +1,240 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/DrFaust92/bitbucket-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// variableClient lists, creates, updates and deletes the pipeline variables of one repository or deployment
+// environment. The bulk variable resources reconcile their variable set through it, matching variables by key.
+type variableClient struct {
+	// name is the kind of variable in errors, e.g. Repository Variable
+	name   string
+	list   func(ctx context.Context) ([]bitbucket.PipelineVariable, error)
+	create func(ctx context.Context, variable bitbucket.PipelineVariable) error
+	update func(ctx context.Context, variable bitbucket.PipelineVariable) error
+	delete func(ctx context.Context, uuid string) error
+}
+
+// variablesSchema is the schema shared by the bulk variable resources, owner names the repository or deployment the
+// variables belong to
+func variablesSchema(owner string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		owner: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"exclusive": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"variable": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"key": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"value": {
+						Type:      schema.TypeString,
+						Required:  true,
+						Sensitive: true,
+					},
+					"secured": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandPipelineVariables returns the variables of the set by their key
+func expandPipelineVariables(set *schema.Set) (map[string]bitbucket.PipelineVariable, error) {
+	variables := make(map[string]bitbucket.PipelineVariable, set.Len())
+	for _, item := range set.List() {
+		tfMap := item.(map[string]interface{})
+		variable := bitbucket.PipelineVariable{
+			Key:     tfMap["key"].(string),
+			Value:   tfMap["value"].(string),
+			Secured: tfMap["secured"].(bool),
+		}
+		if _, ok := variables[variable.Key]; ok {
+			return nil, fmt.Errorf("variable %s is configured more than once", variable.Key)
+		}
+		variables[variable.Key] = variable
+	}
+	return variables, nil
+}
+
+// flattenPipelineVariables returns the variables for the variable set. Bitbucket never returns the value of a secured
+// variable, it is taken from known instead.
+func flattenPipelineVariables(variables []bitbucket.PipelineVariable, known map[string]bitbucket.PipelineVariable) []interface{} {
+	tfList := make([]interface{}, 0, len(variables))
+	for _, variable := range variables {
+		value := variable.Value
+		if variable.Secured {
+			value = known[variable.Key].Value
+		}
+		tfList = append(tfList, map[string]interface{}{
+			"key":     variable.Key,
+			"value":   value,
+			"secured": variable.Secured,
+		})
+	}
+	return tfList
+}
+
+// readVariables sets the variable set of d from the variables c lists. Without exclusive only the variables d
+// already manages are set. Errors of c.list, such as a *NotFoundError, are returned unchanged.
+func readVariables(ctx context.Context, d *schema.ResourceData, c variableClient) error {
+	variables, err := c.list(ctx)
+	if err != nil {
+		return err
+	}
+
+	managed, err := expandPipelineVariables(d.Get("variable").(*schema.Set))
+	if err != nil {
+		return err
+	}
+
+	// without exclusive, variables that aren't managed by the resource are none of its business
+	if !d.Get("exclusive").(bool) {
+		filtered := make([]bitbucket.PipelineVariable, 0, len(managed))
+		for _, variable := range variables {
+			if _, ok := managed[variable.Key]; ok {
+				filtered = append(filtered, variable)
+			}
+		}
+		variables = filtered
+	}
+
+	d.Set("variable", flattenPipelineVariables(variables, managed))
+
+	return nil
+}
+
+// reconcileVariables creates, updates and deletes variables until they match the variable set of d. Variables that
+// were removed from the set are deleted, with exclusive every other variable that isn't in the set is deleted too.
+func reconcileVariables(ctx context.Context, d *schema.ResourceData, c variableClient) error {
+	o, n := d.GetChange("variable")
+	old, err := expandPipelineVariables(o.(*schema.Set))
+	if err != nil {
+		return err
+	}
+	desired, err := expandPipelineVariables(n.(*schema.Set))
+	if err != nil {
+		return err
+	}
+
+	variables, err := c.list(ctx)
+	if err != nil {
+		return fmt.Errorf("error reading %ss (%s): %w", c.name, d.Id(), err)
+	}
+
+	existing := make(map[string]bitbucket.PipelineVariable, len(variables))
+	for _, variable := range variables {
+		existing[variable.Key] = variable
+	}
+
+	for key, variable := range existing {
+		want, wanted := desired[key]
+		_, known := old[key]
+
+		switch {
+		case wanted && !(variable.Secured && !want.Secured):
+			// kept, a secured variable can't be turned back into a plain one though, it is replaced instead
+			continue
+		case !wanted && !known && !d.Get("exclusive").(bool):
+			// not managed by the resource
+			continue
+		}
+
+		log.Printf("[DEBUG] Deleting %s %s (%s)", c.name, key, d.Id())
+		if err := c.delete(ctx, variable.Uuid); err != nil && !IsNotFound(err) {
+			return fmt.Errorf("error deleting %s %s (%s): %w", c.name, key, d.Id(), err)
+		}
+		delete(existing, key)
+	}
+
+	for key, variable := range desired {
+		current, ok := existing[key]
+		if !ok {
+			log.Printf("[DEBUG] Creating %s %s (%s)", c.name, key, d.Id())
+			if err := c.create(ctx, variable); err != nil {
+				return fmt.Errorf("error creating %s %s (%s): %w", c.name, key, d.Id(), err)
+			}
+			continue
+		}
+
+		// the value of a secured variable is unknown, it is only sent when the configuration changed
+		unchanged := current.Secured == variable.Secured && current.Value == variable.Value
+		if current.Secured {
+			unchanged = old[key] == variable
+		}
+		if unchanged {
+			continue
+		}
+
+		log.Printf("[DEBUG] Updating %s %s (%s)", c.name, key, d.Id())
+		variable.Uuid = current.Uuid
+		if err := c.update(ctx, variable); err != nil {
+			return fmt.Errorf("error updating %s %s (%s): %w", c.name, key, d.Id(), err)
+		}
+	}
+
+	return nil
+}
+
+// deleteVariables deletes the variables managed by d, the others are left alone even with exclusive
+func deleteVariables(ctx context.Context, d *schema.ResourceData, c variableClient) error {
+	variables, err := c.list(ctx)
+	if IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading %ss (%s): %w", c.name, d.Id(), err)
+	}
+
+	managed, err := expandPipelineVariables(d.Get("variable").(*schema.Set))
+	if err != nil {
+		return err
+	}
+
+	for _, variable := range variables {
+		if _, ok := managed[variable.Key]; !ok {
+			continue
+		}
+
+		if err := c.delete(ctx, variable.Uuid); err != nil && !IsNotFound(err) {
+			return fmt.Errorf("error deleting %s %s (%s): %w", c.name, variable.Key, d.Id(), err)
+		}
+	}
+
+	return nil
+}
+
+// importVariables takes over every variable c lists, the values of secured variables are unknown until they are
+// configured
+func importVariables(ctx context.Context, d *schema.ResourceData, c variableClient) error {
+	variables, err := c.list(ctx)
+	if err != nil {
+		return fmt.Errorf("error reading %ss (%s): %w", c.name, d.Id(), err)
+	}
+
+	d.Set("exclusive", false)
+	d.Set("variable", flattenPipelineVariables(variables, nil))
+
+	return nil
+}
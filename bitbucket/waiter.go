@@ -0,0 +1,48 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// the states of the objects polled by waitForObject
+const (
+	objectStatePending = "pending"
+	objectStateReady   = "ready"
+)
+
+// waitForObject polls refresh until the object it reads is ready or timeout passes. Bitbucket is eventually consistent,
+// objects aren't necessarily readable right after they were created and forks are copied asynchronously. refresh
+// reports whether the object is ready, a not found error means it isn't provisioned yet and keeps the waiter polling.
+func waitForObject(ctx context.Context, timeout time.Duration, description string, refresh func() (bool, error)) error {
+	conf := &resource.StateChangeConf{
+		Pending: []string{objectStatePending},
+		Target:  []string{objectStateReady},
+		Refresh: func() (interface{}, string, error) {
+			ready, err := refresh()
+			if IsNotFound(err) {
+				log.Printf("[DEBUG] %s not found yet, waiting for it to be provisioned", description)
+				return false, objectStatePending, nil
+			}
+			if err != nil {
+				return nil, "", err
+			}
+			if !ready {
+				log.Printf("[DEBUG] %s is not ready yet, waiting", description)
+				return false, objectStatePending, nil
+			}
+			return true, objectStateReady, nil
+		},
+		Timeout: timeout,
+	}
+
+	if _, err := conf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for %s to become available: %w", description, err)
+	}
+
+	return nil
+}
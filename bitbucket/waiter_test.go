@@ -0,0 +1,55 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-bitbucket/internal/bitbucketapi"
+)
+
+func TestWaitForObject_pollsUntilReady(t *testing.T) {
+	var calls int
+	err := waitForObject(context.Background(), time.Minute, "thing", func() (bool, error) {
+		calls++
+		switch calls {
+		case 1:
+			return false, &bitbucketapi.NotFoundError{Endpoint: "things/1"}
+		case 2:
+			return false, nil
+		default:
+			return true, nil
+		}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 polls, got %d", calls)
+	}
+}
+
+func TestWaitForObject_stopsOnError(t *testing.T) {
+	var calls int
+	err := waitForObject(context.Background(), time.Minute, "thing", func() (bool, error) {
+		calls++
+		return false, errors.New("boom")
+	})
+	if err == nil || !strings.Contains(err.Error(), "error waiting for thing to become available: boom") {
+		t.Fatalf("expected the error to be returned, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single poll, got %d", calls)
+	}
+}
+
+func TestWaitForObject_timeout(t *testing.T) {
+	err := waitForObject(context.Background(), 300*time.Millisecond, "thing", func() (bool, error) {
+		return false, &bitbucketapi.NotFoundError{Endpoint: "things/1"}
+	})
+	if err == nil || !strings.Contains(err.Error(), "timeout while waiting") {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+// Package bitbucketapi is the typed api for the endpoints the generated client doesn't cover. It sends its requests
+// through the Requester of the provider, which takes care of the authentication, the retries and the read only mode.
+package bitbucketapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// Requester sends the requests of the api, endpoints are relative to the base url of the requester. A 404 response
+// is returned as a *NotFoundError.
+type Requester interface {
+	Do(ctx context.Context, method, endpoint string, payload *bytes.Buffer, addJsonHeader bool) (*http.Response, error)
+	Get(ctx context.Context, endpoint string) (*http.Response, error)
+}
+
+// NotFoundError is returned when the requested object does not exist, e.g. because it was deleted outside of
+// terraform. It wraps the Error returned by the api.
+type NotFoundError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// API is the typed layer on top of a Requester. Every method path escapes the workspaces, slugs and braced uuids it
+// puts into the endpoint, decodes the response into the type of the endpoint and returns the errors of the
+// Requester, such as a *NotFoundError, unchanged.
+type API struct {
+	Branches              *BranchesService
+	BranchingModels       *BranchingModelsService
+	Commits               *CommitsService
+	DefaultReviewers      *DefaultReviewersService
+	DeployKeys            *DeployKeysService
+	DeploymentVariables   *DeploymentVariablesService
+	Environments          *EnvironmentsService
+	Groups                *GroupsService
+	Hooks                 *HooksService
+	ProjectPermissions    *ProjectPermissionsService
+	RepositoryPermissions *RepositoryPermissionsService
+	RepositoryVariables   *RepositoryVariablesService
+	Tags                  *TagsService
+	WorkspaceHooks        *WorkspaceHooksService
+	WorkspaceVariables    *WorkspaceVariablesService
+}
+
+// New returns the typed api on top of client
+func New(client Requester) *API {
+	s := apiService{client: client}
+
+	return &API{
+		Branches:              &BranchesService{s},
+		BranchingModels:       &BranchingModelsService{s},
+		Commits:               &CommitsService{s},
+		DefaultReviewers:      &DefaultReviewersService{s},
+		DeployKeys:            &DeployKeysService{s},
+		DeploymentVariables:   &DeploymentVariablesService{s},
+		Environments:          &EnvironmentsService{s},
+		Groups:                &GroupsService{s},
+		Hooks:                 &HooksService{s},
+		ProjectPermissions:    &ProjectPermissionsService{s},
+		RepositoryPermissions: &RepositoryPermissionsService{s},
+		RepositoryVariables:   &RepositoryVariablesService{s},
+		Tags:                  &TagsService{s},
+		WorkspaceHooks:        &WorkspaceHooksService{s},
+		WorkspaceVariables:    &WorkspaceVariablesService{s},
+	}
+}
+
+// apiService is embedded by the services of API
+type apiService struct {
+	client Requester
+}
+
+func (s apiService) do(ctx context.Context, method, endpoint string, in, out interface{}) error {
+	return DoJSON(ctx, s.client, method, endpoint, in, out)
+}
+
+// Endpoint formats the endpoint with the path escaped segments, e.g.
+// Endpoint("2.0/repositories/%s/%s/hooks/%s", workspace, repoSlug, "{uuid}")
+func Endpoint(format string, segments ...string) string {
+	escaped := make([]interface{}, 0, len(segments))
+	for _, segment := range segments {
+		escaped = append(escaped, url.PathEscape(segment))
+	}
+
+	return fmt.Sprintf(format, escaped...)
+}
+
+// DoJSON sends in as json to endpoint and decodes the response into out. A nil in sends no body, a nil out ignores
+// the response.
+func DoJSON(ctx context.Context, client Requester, method, endpoint string, in, out interface{}) error {
+	var payload *bytes.Buffer
+	if in != nil {
+		body, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewBuffer(body)
+	}
+
+	res, err := client.Do(ctx, method, endpoint, payload, true)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("error decoding %s: %w", endpoint, err)
+	}
+
+	return nil
+}
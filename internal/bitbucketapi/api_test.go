@@ -0,0 +1,97 @@
+package bitbucketapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testRequester sends the requests of the tests to baseURL without authentication or retries
+type testRequester struct {
+	baseURL string
+}
+
+func (r testRequester) Do(ctx context.Context, method, endpoint string, payload *bytes.Buffer, addJsonHeader bool) (*http.Response, error) {
+	if !strings.HasPrefix(endpoint, "http") {
+		endpoint = r.baseURL + "/" + endpoint
+	}
+
+	var body io.Reader = http.NoBody
+	if payload != nil {
+		body = payload
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return res, &NotFoundError{Endpoint: endpoint, Err: fmt.Errorf("%s not found", endpoint)}
+	}
+
+	return res, nil
+}
+
+func (r testRequester) Get(ctx context.Context, endpoint string) (*http.Response, error) {
+	return r.Do(ctx, http.MethodGet, endpoint, nil, true)
+}
+
+func TestAPI_escapesPathSegments(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"uuid":"{a1b2}","name":"staging","environment_type":{"name":"Staging"}}`)
+	}))
+	defer server.Close()
+
+	api := New(testRequester{server.URL})
+	ctx := context.Background()
+
+	if _, err := api.Hooks.Get(ctx, "my team", "repo", "{a1b2}"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := api.Environments.Get(ctx, "ws", "repo", "{a1b2}"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := api.Groups.AddMember(ctx, "ws", "developers", "{a1b2}"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{
+		"GET /2.0/repositories/my%20team/repo/hooks/%7Ba1b2%7D",
+		"GET /2.0/repositories/ws/repo/environments/%7Ba1b2%7D",
+		"PUT /1.0/groups/ws/developers/members/%7Ba1b2%7D",
+	}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %q, got %q", expected, paths)
+	}
+}
+
+func TestAPI_groupsNullIsNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `null`)
+	}))
+	defer server.Close()
+
+	api := New(testRequester{server.URL})
+
+	var notFound *NotFoundError
+	if _, err := api.Groups.Get(context.Background(), "ws", "gone"); !errors.As(err, &notFound) {
+		t.Errorf("expected a null group to be not found, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package bitbucketapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DrFaust92/bitbucket-go-client"
+)
+
+// Deployment structure for handling key info
+type Deployment struct {
+	Name  string `json:"name"`
+	Stage *Stage `json:"environment_type"`
+	UUID  string `json:"uuid,omitempty"`
+}
+
+type Stage struct {
+	Name string `json:"name"`
+}
+
+// sshKey is the data we need to send to create a new SSH Key for the repository
+type SshKey struct {
+	ID      int    `json:"id,omitempty"`
+	UUID    string `json:"uuid,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Label   string `json:"label,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// EnvironmentsService manages the deployment environments of repositories
+type EnvironmentsService struct{ apiService }
+
+// Create adds deployment to the environments of the repository repoSlug
+func (s *EnvironmentsService) Create(ctx context.Context, workspace, repoSlug string, deployment *Deployment) (*Deployment, error) {
+	var created Deployment
+	err := s.do(ctx, http.MethodPost, Endpoint("2.0/repositories/%s/%s/environments/", workspace, repoSlug), deployment, &created)
+	return &created, err
+}
+
+// Get returns the environment with uuid of the repository repoSlug
+func (s *EnvironmentsService) Get(ctx context.Context, workspace, repoSlug, uuid string) (*Deployment, error) {
+	var deployment Deployment
+	err := s.do(ctx, http.MethodGet, Endpoint("2.0/repositories/%s/%s/environments/%s", workspace, repoSlug, uuid), nil, &deployment)
+	return &deployment, err
+}
+
+// Update changes the environment with uuid of the repository repoSlug
+func (s *EnvironmentsService) Update(ctx context.Context, workspace, repoSlug, uuid string, deployment *Deployment) error {
+	return s.do(ctx, http.MethodPut, Endpoint("2.0/repositories/%s/%s/environments/%s", workspace, repoSlug, uuid), deployment, nil)
+}
+
+// Delete removes the environment with uuid from the repository repoSlug
+func (s *EnvironmentsService) Delete(ctx context.Context, workspace, repoSlug, uuid string) error {
+	return s.do(ctx, http.MethodDelete, Endpoint("2.0/repositories/%s/%s/environments/%s", workspace, repoSlug, uuid), nil, nil)
+}
+
+// DeploymentVariablesService reads the variables of deployment environments
+type DeploymentVariablesService struct{ apiService }
+
+// List returns every variable of the environment with uuid of the repository repoSlug
+func (s *DeploymentVariablesService) List(ctx context.Context, workspace, repoSlug, uuid string) ([]bitbucket.DeploymentVariable, error) {
+	return Paginate[bitbucket.DeploymentVariable](ctx, s.client,
+		Endpoint("2.0/repositories/%s/%s/deployments_config/environments/%s/variables", workspace, repoSlug, uuid),
+		&PaginationOptions{PageLen: 100})
+}
+
+// DeployKeysService manages the access keys of repositories
+type DeployKeysService struct{ apiService }
+
+// Create adds key to the access keys of the repository repoSlug
+func (s *DeployKeysService) Create(ctx context.Context, workspace, repoSlug string, key *bitbucket.SshAccountKey) (*SshKey, error) {
+	var created SshKey
+	err := s.do(ctx, http.MethodPost, Endpoint("2.0/repositories/%s/%s/deploy-keys", workspace, repoSlug), key, &created)
+	return &created, err
+}
+
+// Update changes the label of the access key with keyID of the repository repoSlug
+func (s *DeployKeysService) Update(ctx context.Context, workspace, repoSlug, keyID string, key *bitbucket.SshAccountKey) error {
+	return s.do(ctx, http.MethodPut, Endpoint("2.0/repositories/%s/%s/deploy-keys/%s", workspace, repoSlug, keyID), key, nil)
+}
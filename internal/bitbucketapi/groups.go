@@ -0,0 +1,97 @@
+package bitbucketapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+type UserGroup struct {
+	Name                    string `json:"name,omitempty"`
+	Slug                    string `json:"slug,omitempty"`
+	AutoAdd                 bool   `json:"auto_add,omitempty"`
+	Permission              string `json:"permission,omitempty"`
+	EmailForwardingDisabled bool   `json:"email_forwarding_disabled,omitempty"`
+}
+
+type UserGroupMembership struct {
+	UUID string `json:"uuid,omitempty"`
+}
+
+// GroupsService manages the groups of workspaces and their members through the 1.0 api
+type GroupsService struct{ apiService }
+
+// Create adds a group called name to workspace, bitbucket derives its slug from the name
+func (s *GroupsService) Create(ctx context.Context, workspace, name string) (*UserGroup, error) {
+	endpoint := Endpoint("1.0/groups/%s", workspace)
+
+	// the 1.0 api takes a form instead of json
+	res, err := s.client.Do(ctx, http.MethodPost, endpoint, bytes.NewBufferString(url.Values{"name": {name}}.Encode()), false)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var group UserGroup
+	if err := json.Unmarshal(body, &group); err != nil {
+		return nil, fmt.Errorf("error decoding %s: %w", endpoint, err)
+	}
+
+	return &group, nil
+}
+
+// List returns the groups of workspace
+func (s *GroupsService) List(ctx context.Context, workspace string) ([]*UserGroup, error) {
+	return Paginate[*UserGroup](ctx, s.client, Endpoint("1.0/groups/%s", workspace), nil)
+}
+
+// Get returns the group with slug of workspace
+func (s *GroupsService) Get(ctx context.Context, workspace, slug string) (*UserGroup, error) {
+	endpoint := Endpoint("1.0/groups/%s/%s", workspace, slug)
+
+	var group *UserGroup
+	if err := s.do(ctx, http.MethodGet, endpoint, nil, &group); err != nil {
+		return nil, err
+	}
+
+	// the 1.0 api answers with null for groups that are gone
+	if group == nil {
+		return nil, &NotFoundError{Endpoint: endpoint, Err: fmt.Errorf("group %s/%s not found", workspace, slug)}
+	}
+
+	return group, nil
+}
+
+// Update changes the group with slug of workspace
+func (s *GroupsService) Update(ctx context.Context, workspace, slug string, group *UserGroup) error {
+	return s.do(ctx, http.MethodPut, Endpoint("1.0/groups/%s/%s/", workspace, slug), group, nil)
+}
+
+// Delete removes the group with slug from workspace
+func (s *GroupsService) Delete(ctx context.Context, workspace, slug string) error {
+	return s.do(ctx, http.MethodDelete, Endpoint("1.0/groups/%s/%s", workspace, slug), nil, nil)
+}
+
+// Members returns the members of the group with slug of workspace
+func (s *GroupsService) Members(ctx context.Context, workspace, slug string) ([]*UserGroupMembership, error) {
+	return Paginate[*UserGroupMembership](ctx, s.client, Endpoint("1.0/groups/%s/%s/members", workspace, slug), nil)
+}
+
+// AddMember adds the user with uuid to the group with slug of workspace
+func (s *GroupsService) AddMember(ctx context.Context, workspace, slug, uuid string) error {
+	return s.do(ctx, http.MethodPut, Endpoint("1.0/groups/%s/%s/members/%s", workspace, slug, uuid), nil, nil)
+}
+
+// RemoveMember removes the user with uuid from the group with slug of workspace
+func (s *GroupsService) RemoveMember(ctx context.Context, workspace, slug, uuid string) error {
+	return s.do(ctx, http.MethodDelete, Endpoint("1.0/groups/%s/%s/members/%s", workspace, slug, uuid), nil, nil)
+}
@@ -0,0 +1,74 @@
+package bitbucketapi
+
+import (
+	"context"
+	"net/http"
+)
+
+// Hook is the hook you want to add to a bitbucket repository
+type Hook struct {
+	UUID                 string   `json:"uuid,omitempty"`
+	URL                  string   `json:"url,omitempty"`
+	Description          string   `json:"description,omitempty"`
+	Active               bool     `json:"active"`
+	SkipCertVerification bool     `json:"skip_cert_verification"`
+	Events               []string `json:"events,omitempty"`
+}
+
+// HooksService manages the webhooks of repositories
+type HooksService struct{ apiService }
+
+// Create adds hook to the repository repoSlug
+func (s *HooksService) Create(ctx context.Context, workspace, repoSlug string, hook *Hook) (*Hook, error) {
+	var created Hook
+	err := s.do(ctx, http.MethodPost, Endpoint("2.0/repositories/%s/%s/hooks", workspace, repoSlug), hook, &created)
+	return &created, err
+}
+
+// Get returns the webhook with uuid of the repository repoSlug
+func (s *HooksService) Get(ctx context.Context, workspace, repoSlug, uuid string) (*Hook, error) {
+	var hook Hook
+	err := s.do(ctx, http.MethodGet, Endpoint("2.0/repositories/%s/%s/hooks/%s", workspace, repoSlug, uuid), nil, &hook)
+	return &hook, err
+}
+
+// Update replaces the webhook with uuid of the repository repoSlug
+func (s *HooksService) Update(ctx context.Context, workspace, repoSlug, uuid string, hook *Hook) (*Hook, error) {
+	var updated Hook
+	err := s.do(ctx, http.MethodPut, Endpoint("2.0/repositories/%s/%s/hooks/%s", workspace, repoSlug, uuid), hook, &updated)
+	return &updated, err
+}
+
+// Delete removes the webhook with uuid from the repository repoSlug
+func (s *HooksService) Delete(ctx context.Context, workspace, repoSlug, uuid string) error {
+	return s.do(ctx, http.MethodDelete, Endpoint("2.0/repositories/%s/%s/hooks/%s", workspace, repoSlug, uuid), nil, nil)
+}
+
+// WorkspaceHooksService manages the webhooks of workspaces
+type WorkspaceHooksService struct{ apiService }
+
+// Create adds hook to workspace
+func (s *WorkspaceHooksService) Create(ctx context.Context, workspace string, hook *Hook) (*Hook, error) {
+	var created Hook
+	err := s.do(ctx, http.MethodPost, Endpoint("2.0/workspaces/%s/hooks", workspace), hook, &created)
+	return &created, err
+}
+
+// Get returns the webhook with uuid of workspace
+func (s *WorkspaceHooksService) Get(ctx context.Context, workspace, uuid string) (*Hook, error) {
+	var hook Hook
+	err := s.do(ctx, http.MethodGet, Endpoint("2.0/workspaces/%s/hooks/%s", workspace, uuid), nil, &hook)
+	return &hook, err
+}
+
+// Update replaces the webhook with uuid of workspace
+func (s *WorkspaceHooksService) Update(ctx context.Context, workspace, uuid string, hook *Hook) (*Hook, error) {
+	var updated Hook
+	err := s.do(ctx, http.MethodPut, Endpoint("2.0/workspaces/%s/hooks/%s", workspace, uuid), hook, &updated)
+	return &updated, err
+}
+
+// Delete removes the webhook with uuid from workspace
+func (s *WorkspaceHooksService) Delete(ctx context.Context, workspace, uuid string) error {
+	return s.do(ctx, http.MethodDelete, Endpoint("2.0/workspaces/%s/hooks/%s", workspace, uuid), nil, nil)
+}
@@ -0,0 +1,108 @@
+package bitbucketapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PaginationOptions narrows down and orders the results of a list endpoint
+type PaginationOptions struct {
+	// PageLen is the number of values per page, bitbucket's default is used when it is zero
+	PageLen int
+	// Query is a bitbucket filter expression, e.g. `key = "FOO"`
+	Query string
+	// Sort is the field to sort by, prefix with `-` to reverse the order
+	Sort string
+}
+
+// paginatedValues is the envelope bitbucket wraps the values of list endpoints in
+type paginatedValues struct {
+	Values []json.RawMessage `json:"values"`
+	Next   string            `json:"next,omitempty"`
+}
+
+// Paginate fetches every page of the list endpoint and returns all values. It follows the `next` link of each page
+// and stops as soon as ctx is done. Legacy 1.0 endpoints that return a plain json array are returned as is.
+func Paginate[T any](ctx context.Context, c Requester, endpoint string, options *PaginationOptions) ([]T, error) {
+	pageURL, err := paginationURL(endpoint, options)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]T, 0)
+
+	for pageURL != "" {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		res, err := c.Get(ctx, pageURL)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+			var page []T
+			if err := json.Unmarshal(trimmed, &page); err != nil {
+				return nil, fmt.Errorf("error decoding %s: %w", endpoint, err)
+			}
+			return append(values, page...), nil
+		}
+
+		var page paginatedValues
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("error decoding %s: %w", endpoint, err)
+		}
+
+		for _, raw := range page.Values {
+			var value T
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil, fmt.Errorf("error decoding %s: %w", endpoint, err)
+			}
+			values = append(values, value)
+		}
+
+		pageURL = page.Next
+	}
+
+	return values, nil
+}
+
+// paginationURL adds the query parameters of options to endpoint
+func paginationURL(endpoint string, options *PaginationOptions) (string, error) {
+	if options == nil {
+		return endpoint, nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	if options.PageLen > 0 {
+		query.Set("pagelen", strconv.Itoa(options.PageLen))
+	}
+	if options.Query != "" {
+		query.Set("q", options.Query)
+	}
+	if options.Sort != "" {
+		query.Set("sort", options.Sort)
+	}
+
+	u.RawQuery = strings.ReplaceAll(query.Encode(), "+", "%20")
+
+	return u.String(), nil
+}
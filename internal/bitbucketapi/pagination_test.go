@@ -0,0 +1,80 @@
+package bitbucketapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPaginate_followsNextLinks(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pagelen") != "2" || r.URL.Query().Get("q") != `key = "FOO"` || r.URL.Query().Get("sort") != "-key" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+
+		switch r.URL.Query().Get("page") {
+		case "":
+			fmt.Fprintf(w, `{"values":[{"uuid":"a"},{"uuid":"b"}],"next":"%s/2.0/items?pagelen=2&q=key+%%3D+%%22FOO%%22&sort=-key&page=2"}`, server.URL)
+		case "2":
+			fmt.Fprint(w, `{"values":[{"uuid":"c"}]}`)
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	}))
+	defer server.Close()
+
+	client := testRequester{server.URL}
+	values, err := Paginate[Reviewer](context.Background(), client, "2.0/items", &PaginationOptions{
+		PageLen: 2,
+		Query:   `key = "FOO"`,
+		Sort:    "-key",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(values) != 3 || values[0].UUID != "a" || values[2].UUID != "c" {
+		t.Fatalf("expected values from both pages, got %#v", values)
+	}
+}
+
+func TestPaginate_plainArray(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"slug":"developers"},{"slug":"admins"}]`)
+	}))
+	defer server.Close()
+
+	client := testRequester{server.URL}
+	groups, err := Paginate[*UserGroup](context.Background(), client, "1.0/groups/ws", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(groups) != 2 || groups[1].Slug != "admins" {
+		t.Fatalf("expected both groups, got %#v", groups)
+	}
+}
+
+func TestPaginate_stopsOnCancelledContext(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"values":[],"next":"2.0/items?page=2"}`)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := testRequester{server.URL}
+	if _, err := Paginate[Reviewer](ctx, client, "2.0/items", nil); err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	if calls != 0 {
+		t.Fatalf("expected no requests, got %d", calls)
+	}
+}
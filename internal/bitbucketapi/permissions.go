@@ -0,0 +1,21 @@
+package bitbucketapi
+
+// Permission is the explicit permission of a user or group on a repository or project
+type Permission struct {
+	Permission string           `json:"permission"`
+	User       *PermissionUser  `json:"user,omitempty"`
+	Group      *PermissionGroup `json:"group,omitempty"`
+}
+
+// PermissionUser is the user a permission is granted to
+type PermissionUser struct {
+	UUID        string `json:"uuid,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	AccountID   string `json:"account_id,omitempty"`
+}
+
+// PermissionGroup is the group a permission is granted to
+type PermissionGroup struct {
+	Slug string `json:"slug,omitempty"`
+	Name string `json:"name,omitempty"`
+}
@@ -0,0 +1,212 @@
+package bitbucketapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DrFaust92/bitbucket-go-client"
+)
+
+// BranchingModel is the data we need to send to create a new branching model for the repository
+type BranchingModel struct {
+	Development *BranchModel  `json:"development,omitempty"`
+	Production  *BranchModel  `json:"production,omitempty"`
+	BranchTypes []*BranchType `json:"branch_types"`
+}
+
+type BranchModel struct {
+	IsValid            bool   `json:"is_valid,omitempty"`
+	Name               string `json:"name,omitempty"`
+	UseMainbranch      bool   `json:"use_mainbranch,omitempty"`
+	BranchDoesNotExist bool   `json:"branch_does_not_exist,omitempty"`
+	Enabled            bool   `json:"enabled,omitempty"`
+}
+
+type BranchType struct {
+	Enabled bool   `json:"enabled,omitempty"`
+	Kind    string `json:"kind,omitempty"`
+	Prefix  string `json:"prefix,omitempty"`
+}
+
+// Reviewer is teh default reviewer you want
+type Reviewer struct {
+	DisplayName string `json:"display_name,omitempty"`
+	UUID        string `json:"uuid,omitempty"`
+	Type        string `json:"type,omitempty"`
+}
+
+// Branch is a branch of a repository and the commit it points at
+type Branch struct {
+	Name   string  `json:"name,omitempty"`
+	Target *Commit `json:"target,omitempty"`
+}
+
+// Commit is the commit a branch or tag points at
+type Commit struct {
+	Hash string `json:"hash,omitempty"`
+}
+
+// Tag is a tag of a repository and the commit it points at
+type Tag struct {
+	Name    string  `json:"name,omitempty"`
+	Target  *Commit `json:"target,omitempty"`
+	Message string  `json:"message,omitempty"`
+}
+
+// BranchingModelsService manages the branching model of repositories
+type BranchingModelsService struct{ apiService }
+
+// Get returns the effective branching model of the repository repoSlug
+func (s *BranchingModelsService) Get(ctx context.Context, workspace, repoSlug string) (*BranchingModel, error) {
+	var model BranchingModel
+	err := s.do(ctx, http.MethodGet, Endpoint("2.0/repositories/%s/%s/branching-model", workspace, repoSlug), nil, &model)
+	return &model, err
+}
+
+// Update replaces the branching model settings of the repository repoSlug
+func (s *BranchingModelsService) Update(ctx context.Context, workspace, repoSlug string, model *BranchingModel) (*BranchingModel, error) {
+	var updated BranchingModel
+	err := s.do(ctx, http.MethodPut, Endpoint("2.0/repositories/%s/%s/branching-model/settings", workspace, repoSlug), model, &updated)
+	return &updated, err
+}
+
+// Reset restores the default branching model settings of the repository repoSlug
+func (s *BranchingModelsService) Reset(ctx context.Context, workspace, repoSlug string) error {
+	return s.do(ctx, http.MethodPut, Endpoint("2.0/repositories/%s/%s/branching-model/settings", workspace, repoSlug), nil, nil)
+}
+
+// DefaultReviewersService reads the default reviewers of repositories
+type DefaultReviewersService struct{ apiService }
+
+// List returns the default reviewers of the repository repoSlug
+func (s *DefaultReviewersService) List(ctx context.Context, workspace, repoSlug string) ([]Reviewer, error) {
+	return Paginate[Reviewer](ctx, s.client, Endpoint("2.0/repositories/%s/%s/default-reviewers", workspace, repoSlug),
+		&PaginationOptions{PageLen: 100})
+}
+
+// RepositoryVariablesService reads the pipeline variables of repositories
+type RepositoryVariablesService struct{ apiService }
+
+// List returns every pipeline variable of the repository repoSlug
+func (s *RepositoryVariablesService) List(ctx context.Context, workspace, repoSlug string) ([]bitbucket.PipelineVariable, error) {
+	return Paginate[bitbucket.PipelineVariable](ctx, s.client,
+		Endpoint("2.0/repositories/%s/%s/pipelines_config/variables", workspace, repoSlug),
+		&PaginationOptions{PageLen: 100})
+}
+
+// RepositoryPermissionsService manages the explicit permissions users and groups have on repositories
+type RepositoryPermissionsService struct{ apiService }
+
+// ListUsers returns the explicit user permissions of the repository repoSlug
+func (s *RepositoryPermissionsService) ListUsers(ctx context.Context, workspace, repoSlug string) ([]Permission, error) {
+	return Paginate[Permission](ctx, s.client,
+		Endpoint("2.0/repositories/%s/%s/permissions-config/users", workspace, repoSlug),
+		&PaginationOptions{PageLen: 100})
+}
+
+// GetUser returns the explicit permission of the user with userID on the repository repoSlug
+func (s *RepositoryPermissionsService) GetUser(ctx context.Context, workspace, repoSlug, userID string) (*Permission, error) {
+	var permission Permission
+	err := s.do(ctx, http.MethodGet,
+		Endpoint("2.0/repositories/%s/%s/permissions-config/users/%s", workspace, repoSlug, userID), nil, &permission)
+	return &permission, err
+}
+
+// UpdateUser grants the user with userID permission on the repository repoSlug
+func (s *RepositoryPermissionsService) UpdateUser(ctx context.Context, workspace, repoSlug, userID, permission string) (*Permission, error) {
+	var updated Permission
+	err := s.do(ctx, http.MethodPut,
+		Endpoint("2.0/repositories/%s/%s/permissions-config/users/%s", workspace, repoSlug, userID),
+		&Permission{Permission: permission}, &updated)
+	return &updated, err
+}
+
+// DeleteUser revokes the explicit permission of the user with userID on the repository repoSlug
+func (s *RepositoryPermissionsService) DeleteUser(ctx context.Context, workspace, repoSlug, userID string) error {
+	return s.do(ctx, http.MethodDelete,
+		Endpoint("2.0/repositories/%s/%s/permissions-config/users/%s", workspace, repoSlug, userID), nil, nil)
+}
+
+// ListGroups returns the explicit group permissions of the repository repoSlug
+func (s *RepositoryPermissionsService) ListGroups(ctx context.Context, workspace, repoSlug string) ([]Permission, error) {
+	return Paginate[Permission](ctx, s.client,
+		Endpoint("2.0/repositories/%s/%s/permissions-config/groups", workspace, repoSlug),
+		&PaginationOptions{PageLen: 100})
+}
+
+// GetGroup returns the explicit permission of the group with groupSlug on the repository repoSlug
+func (s *RepositoryPermissionsService) GetGroup(ctx context.Context, workspace, repoSlug, groupSlug string) (*Permission, error) {
+	var permission Permission
+	err := s.do(ctx, http.MethodGet,
+		Endpoint("2.0/repositories/%s/%s/permissions-config/groups/%s", workspace, repoSlug, groupSlug), nil, &permission)
+	return &permission, err
+}
+
+// UpdateGroup grants the group with groupSlug permission on the repository repoSlug
+func (s *RepositoryPermissionsService) UpdateGroup(ctx context.Context, workspace, repoSlug, groupSlug, permission string) (*Permission, error) {
+	var updated Permission
+	err := s.do(ctx, http.MethodPut,
+		Endpoint("2.0/repositories/%s/%s/permissions-config/groups/%s", workspace, repoSlug, groupSlug),
+		&Permission{Permission: permission}, &updated)
+	return &updated, err
+}
+
+// DeleteGroup revokes the explicit permission of the group with groupSlug on the repository repoSlug
+func (s *RepositoryPermissionsService) DeleteGroup(ctx context.Context, workspace, repoSlug, groupSlug string) error {
+	return s.do(ctx, http.MethodDelete,
+		Endpoint("2.0/repositories/%s/%s/permissions-config/groups/%s", workspace, repoSlug, groupSlug), nil, nil)
+}
+
+// BranchesService manages the branches of repositories
+type BranchesService struct{ apiService }
+
+// Create adds branch to the repository repoSlug, its target has to name the hash of a commit
+func (s *BranchesService) Create(ctx context.Context, workspace, repoSlug string, branch *Branch) (*Branch, error) {
+	var created Branch
+	err := s.do(ctx, http.MethodPost, Endpoint("2.0/repositories/%s/%s/refs/branches", workspace, repoSlug), branch, &created)
+	return &created, err
+}
+
+// Get returns the branch called name of the repository repoSlug
+func (s *BranchesService) Get(ctx context.Context, workspace, repoSlug, name string) (*Branch, error) {
+	var branch Branch
+	err := s.do(ctx, http.MethodGet, Endpoint("2.0/repositories/%s/%s/refs/branches/%s", workspace, repoSlug, name), nil, &branch)
+	return &branch, err
+}
+
+// Delete removes the branch called name from the repository repoSlug
+func (s *BranchesService) Delete(ctx context.Context, workspace, repoSlug, name string) error {
+	return s.do(ctx, http.MethodDelete, Endpoint("2.0/repositories/%s/%s/refs/branches/%s", workspace, repoSlug, name), nil, nil)
+}
+
+// CommitsService reads the commits of repositories
+type CommitsService struct{ apiService }
+
+// Get returns the commit revision of the repository repoSlug names, revision is a commit hash or a branch or tag name
+func (s *CommitsService) Get(ctx context.Context, workspace, repoSlug, revision string) (*Commit, error) {
+	var commit Commit
+	err := s.do(ctx, http.MethodGet, Endpoint("2.0/repositories/%s/%s/commit/%s", workspace, repoSlug, revision), nil, &commit)
+	return &commit, err
+}
+
+// TagsService manages the tags of repositories
+type TagsService struct{ apiService }
+
+// Create adds tag to the repository repoSlug, its target has to name the hash of a commit
+func (s *TagsService) Create(ctx context.Context, workspace, repoSlug string, tag *Tag) (*Tag, error) {
+	var created Tag
+	err := s.do(ctx, http.MethodPost, Endpoint("2.0/repositories/%s/%s/refs/tags", workspace, repoSlug), tag, &created)
+	return &created, err
+}
+
+// Get returns the tag called name of the repository repoSlug
+func (s *TagsService) Get(ctx context.Context, workspace, repoSlug, name string) (*Tag, error) {
+	var tag Tag
+	err := s.do(ctx, http.MethodGet, Endpoint("2.0/repositories/%s/%s/refs/tags/%s", workspace, repoSlug, name), nil, &tag)
+	return &tag, err
+}
+
+// Delete removes the tag called name from the repository repoSlug
+func (s *TagsService) Delete(ctx context.Context, workspace, repoSlug, name string) error {
+	return s.do(ctx, http.MethodDelete, Endpoint("2.0/repositories/%s/%s/refs/tags/%s", workspace, repoSlug, name), nil, nil)
+}
@@ -0,0 +1,81 @@
+package bitbucketapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DrFaust92/bitbucket-go-client"
+)
+
+// WorkspaceVariablesService reads the pipeline variables of workspaces
+type WorkspaceVariablesService struct{ apiService }
+
+// List returns every pipeline variable of workspace
+func (s *WorkspaceVariablesService) List(ctx context.Context, workspace string) ([]bitbucket.PipelineVariable, error) {
+	return Paginate[bitbucket.PipelineVariable](ctx, s.client,
+		Endpoint("2.0/workspaces/%s/pipelines-config/variables", workspace),
+		&PaginationOptions{PageLen: 100})
+}
+
+// ProjectPermissionsService manages the explicit permissions users and groups have on projects
+type ProjectPermissionsService struct{ apiService }
+
+// ListUsers returns the explicit user permissions of the project with projectKey
+func (s *ProjectPermissionsService) ListUsers(ctx context.Context, workspace, projectKey string) ([]Permission, error) {
+	return Paginate[Permission](ctx, s.client,
+		Endpoint("2.0/workspaces/%s/projects/%s/permissions-config/users", workspace, projectKey),
+		&PaginationOptions{PageLen: 100})
+}
+
+// GetUser returns the explicit permission of the user with userID on the project with projectKey
+func (s *ProjectPermissionsService) GetUser(ctx context.Context, workspace, projectKey, userID string) (*Permission, error) {
+	var permission Permission
+	err := s.do(ctx, http.MethodGet,
+		Endpoint("2.0/workspaces/%s/projects/%s/permissions-config/users/%s", workspace, projectKey, userID), nil, &permission)
+	return &permission, err
+}
+
+// UpdateUser grants the user with userID permission on the project with projectKey
+func (s *ProjectPermissionsService) UpdateUser(ctx context.Context, workspace, projectKey, userID, permission string) (*Permission, error) {
+	var updated Permission
+	err := s.do(ctx, http.MethodPut,
+		Endpoint("2.0/workspaces/%s/projects/%s/permissions-config/users/%s", workspace, projectKey, userID),
+		&Permission{Permission: permission}, &updated)
+	return &updated, err
+}
+
+// DeleteUser revokes the explicit permission of the user with userID on the project with projectKey
+func (s *ProjectPermissionsService) DeleteUser(ctx context.Context, workspace, projectKey, userID string) error {
+	return s.do(ctx, http.MethodDelete,
+		Endpoint("2.0/workspaces/%s/projects/%s/permissions-config/users/%s", workspace, projectKey, userID), nil, nil)
+}
+
+// ListGroups returns the explicit group permissions of the project with projectKey
+func (s *ProjectPermissionsService) ListGroups(ctx context.Context, workspace, projectKey string) ([]Permission, error) {
+	return Paginate[Permission](ctx, s.client,
+		Endpoint("2.0/workspaces/%s/projects/%s/permissions-config/groups", workspace, projectKey),
+		&PaginationOptions{PageLen: 100})
+}
+
+// GetGroup returns the explicit permission of the group with groupSlug on the project with projectKey
+func (s *ProjectPermissionsService) GetGroup(ctx context.Context, workspace, projectKey, groupSlug string) (*Permission, error) {
+	var permission Permission
+	err := s.do(ctx, http.MethodGet,
+		Endpoint("2.0/workspaces/%s/projects/%s/permissions-config/groups/%s", workspace, projectKey, groupSlug), nil, &permission)
+	return &permission, err
+}
+
+// UpdateGroup grants the group with groupSlug permission on the project with projectKey
+func (s *ProjectPermissionsService) UpdateGroup(ctx context.Context, workspace, projectKey, groupSlug, permission string) (*Permission, error) {
+	var updated Permission
+	err := s.do(ctx, http.MethodPut,
+		Endpoint("2.0/workspaces/%s/projects/%s/permissions-config/groups/%s", workspace, projectKey, groupSlug),
+		&Permission{Permission: permission}, &updated)
+	return &updated, err
+}
+
+// DeleteGroup revokes the explicit permission of the group with groupSlug on the project with projectKey
+func (s *ProjectPermissionsService) DeleteGroup(ctx context.Context, workspace, projectKey, groupSlug string) error {
+	return s.do(ctx, http.MethodDelete,
+		Endpoint("2.0/workspaces/%s/projects/%s/permissions-config/groups/%s", workspace, projectKey, groupSlug), nil, nil)
+}